@@ -25,10 +25,56 @@ type AddOrderRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Pair      string  `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
-	Direction string  `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`
-	Price     float64 `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	Volume    float64 `protobuf:"fixed64,4,opt,name=volume,proto3" json:"volume,omitempty"`
+	// pair is any name of the pair: XBT/EUR, XBTEUR or XXBTZEUR, orders are stored with the websocket name (XBT/EUR)
+	// price and volume are checked against tick size, lot decimals (cost decimals with viqc flag) and minimums of the pair,
+	// values off the grid are rejected with INVALID_ARGUMENT
+	// prices and volumes in all messages are decimal strings, e.g. "0.00123", and are sent to the exchange as is
+	Pair      string `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Direction string `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`
+	// priceDouble and volumeDouble are used by old clients, they are read only if price or volume are empty
+	//
+	// Deprecated: Do not use.
+	PriceDouble float64 `protobuf:"fixed64,3,opt,name=priceDouble,proto3" json:"priceDouble,omitempty"`
+	// Deprecated: Do not use.
+	VolumeDouble float64 `protobuf:"fixed64,4,opt,name=volumeDouble,proto3" json:"volumeDouble,omitempty"`
+	Price        string  `protobuf:"bytes,23,opt,name=price,proto3" json:"price,omitempty"`
+	Volume       string  `protobuf:"bytes,24,opt,name=volume,proto3" json:"volume,omitempty"`
+	// orderType is one of: limit (default), market, stop-loss, stop-loss-limit, take-profit, take-profit-limit,
+	// trailing-stop, trailing-stop-limit, settle-position
+	OrderType string `protobuf:"bytes,5,opt,name=orderType,proto3" json:"orderType,omitempty"`
+	// price2 is a limit price for *-limit orders
+	Price2 string `protobuf:"bytes,25,opt,name=price2,proto3" json:"price2,omitempty"`
+	// Deprecated: Do not use.
+	Price2Double float64 `protobuf:"fixed64,6,opt,name=price2Double,proto3" json:"price2Double,omitempty"`
+	// trigger is a price signal for triggered orders: last (default) or index
+	Trigger string `protobuf:"bytes,7,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	// priceOffset makes price relative: "+" or "-" from the last price, "#" in the direction of the order
+	PriceOffset  string `protobuf:"bytes,8,opt,name=priceOffset,proto3" json:"priceOffset,omitempty"`
+	PricePercent bool   `protobuf:"varint,9,opt,name=pricePercent,proto3" json:"pricePercent,omitempty"`
+	// price2Offset makes price2 relative to the trigger price
+	Price2Offset  string `protobuf:"bytes,10,opt,name=price2Offset,proto3" json:"price2Offset,omitempty"`
+	Price2Percent bool   `protobuf:"varint,11,opt,name=price2Percent,proto3" json:"price2Percent,omitempty"`
+	// leverage is required for settle-position orders
+	Leverage string `protobuf:"bytes,12,opt,name=leverage,proto3" json:"leverage,omitempty"`
+	// oflags are order flags: post, fcib, fciq, nompp, viqc
+	Oflags []string `protobuf:"bytes,13,rep,name=oflags,proto3" json:"oflags,omitempty"`
+	// timeInForce is GTC (default), IOC or GTD, GTD requires expireTime
+	TimeInForce string `protobuf:"bytes,14,opt,name=timeInForce,proto3" json:"timeInForce,omitempty"`
+	// startTime and expireTime: "0" for now, "+<n>" seconds from now or unix timestamp
+	StartTime  string `protobuf:"bytes,15,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	ExpireTime string `protobuf:"bytes,16,opt,name=expireTime,proto3" json:"expireTime,omitempty"`
+	// deadline is RFC3339 time after which the exchange should reject the order
+	Deadline   string `protobuf:"bytes,17,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	ReduceOnly bool   `protobuf:"varint,18,opt,name=reduceOnly,proto3" json:"reduceOnly,omitempty"`
+	// validate only checks the order on the exchange without placing it
+	Validate bool `protobuf:"varint,19,opt,name=validate,proto3" json:"validate,omitempty"`
+	// clientOrderId is an id of the order set by the client: UUID or free text up to 18 characters
+	ClientOrderId string `protobuf:"bytes,20,opt,name=clientOrderId,proto3" json:"clientOrderId,omitempty"`
+	// idempotencyKey makes the request safe to retry: repeated requests with the same key return the result
+	// of the first one instead of placing another order
+	IdempotencyKey string `protobuf:"bytes,21,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
+	// tag is a label to group orders, e.g. by strategy, orders can be listed by it
+	Tag string `protobuf:"bytes,22,opt,name=tag,proto3" json:"tag,omitempty"`
 }
 
 func (x *AddOrderRequest) Reset() {
@@ -77,20 +123,170 @@ func (x *AddOrderRequest) GetDirection() string {
 	return ""
 }
 
-func (x *AddOrderRequest) GetPrice() float64 {
+// Deprecated: Do not use.
+func (x *AddOrderRequest) GetPriceDouble() float64 {
 	if x != nil {
-		return x.Price
+		return x.PriceDouble
+	}
+	return 0
+}
+
+// Deprecated: Do not use.
+func (x *AddOrderRequest) GetVolumeDouble() float64 {
+	if x != nil {
+		return x.VolumeDouble
 	}
 	return 0
 }
 
-func (x *AddOrderRequest) GetVolume() float64 {
+func (x *AddOrderRequest) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *AddOrderRequest) GetVolume() string {
 	if x != nil {
 		return x.Volume
 	}
+	return ""
+}
+
+func (x *AddOrderRequest) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *AddOrderRequest) GetPrice2() string {
+	if x != nil {
+		return x.Price2
+	}
+	return ""
+}
+
+// Deprecated: Do not use.
+func (x *AddOrderRequest) GetPrice2Double() float64 {
+	if x != nil {
+		return x.Price2Double
+	}
 	return 0
 }
 
+func (x *AddOrderRequest) GetTrigger() string {
+	if x != nil {
+		return x.Trigger
+	}
+	return ""
+}
+
+func (x *AddOrderRequest) GetPriceOffset() string {
+	if x != nil {
+		return x.PriceOffset
+	}
+	return ""
+}
+
+func (x *AddOrderRequest) GetPricePercent() bool {
+	if x != nil {
+		return x.PricePercent
+	}
+	return false
+}
+
+func (x *AddOrderRequest) GetPrice2Offset() string {
+	if x != nil {
+		return x.Price2Offset
+	}
+	return ""
+}
+
+func (x *AddOrderRequest) GetPrice2Percent() bool {
+	if x != nil {
+		return x.Price2Percent
+	}
+	return false
+}
+
+func (x *AddOrderRequest) GetLeverage() string {
+	if x != nil {
+		return x.Leverage
+	}
+	return ""
+}
+
+func (x *AddOrderRequest) GetOflags() []string {
+	if x != nil {
+		return x.Oflags
+	}
+	return nil
+}
+
+func (x *AddOrderRequest) GetTimeInForce() string {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return ""
+}
+
+func (x *AddOrderRequest) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *AddOrderRequest) GetExpireTime() string {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return ""
+}
+
+func (x *AddOrderRequest) GetDeadline() string {
+	if x != nil {
+		return x.Deadline
+	}
+	return ""
+}
+
+func (x *AddOrderRequest) GetReduceOnly() bool {
+	if x != nil {
+		return x.ReduceOnly
+	}
+	return false
+}
+
+func (x *AddOrderRequest) GetValidate() bool {
+	if x != nil {
+		return x.Validate
+	}
+	return false
+}
+
+func (x *AddOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *AddOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *AddOrderRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
 type AddOrderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -154,16 +350,16 @@ func (x *AddOrderResponse) GetOrderId() string {
 	return ""
 }
 
-type CancelOrderRequest struct {
+type AddOrdersRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RefId int32 `protobuf:"varint,1,opt,name=refId,proto3" json:"refId,omitempty"`
+	Orders []*AddOrderRequest `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
 }
 
-func (x *CancelOrderRequest) Reset() {
-	*x = CancelOrderRequest{}
+func (x *AddOrdersRequest) Reset() {
+	*x = AddOrdersRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_trader_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -171,13 +367,13 @@ func (x *CancelOrderRequest) Reset() {
 	}
 }
 
-func (x *CancelOrderRequest) String() string {
+func (x *AddOrdersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelOrderRequest) ProtoMessage() {}
+func (*AddOrdersRequest) ProtoMessage() {}
 
-func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+func (x *AddOrdersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_trader_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -189,28 +385,31 @@ func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
-func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddOrdersRequest.ProtoReflect.Descriptor instead.
+func (*AddOrdersRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_trader_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CancelOrderRequest) GetRefId() int32 {
+func (x *AddOrdersRequest) GetOrders() []*AddOrderRequest {
 	if x != nil {
-		return x.RefId
+		return x.Orders
 	}
-	return 0
+	return nil
 }
 
-type CancelOrderResponse struct {
+type AddOrderResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Status  string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	RefId   int32  `protobuf:"varint,2,opt,name=refId,proto3" json:"refId,omitempty"`
+	OrderId string `protobuf:"bytes,3,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	Error   string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (x *CancelOrderResponse) Reset() {
-	*x = CancelOrderResponse{}
+func (x *AddOrderResult) Reset() {
+	*x = AddOrderResult{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_trader_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -218,13 +417,13 @@ func (x *CancelOrderResponse) Reset() {
 	}
 }
 
-func (x *CancelOrderResponse) String() string {
+func (x *AddOrderResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelOrderResponse) ProtoMessage() {}
+func (*AddOrderResult) ProtoMessage() {}
 
-func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+func (x *AddOrderResult) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_trader_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -236,28 +435,50 @@ func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
-func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddOrderResult.ProtoReflect.Descriptor instead.
+func (*AddOrderResult) Descriptor() ([]byte, []int) {
 	return file_api_proto_trader_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *CancelOrderResponse) GetStatus() string {
+func (x *AddOrderResult) GetStatus() string {
 	if x != nil {
 		return x.Status
 	}
 	return ""
 }
 
-type OrderStatusRequest struct {
+func (x *AddOrderResult) GetRefId() int32 {
+	if x != nil {
+		return x.RefId
+	}
+	return 0
+}
+
+func (x *AddOrderResult) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AddOrderResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type AddOrdersResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RefId int32 `protobuf:"varint,1,opt,name=refId,proto3" json:"refId,omitempty"`
+	// results are in the same order as orders in the request
+	Results []*AddOrderResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *OrderStatusRequest) Reset() {
-	*x = OrderStatusRequest{}
+func (x *AddOrdersResponse) Reset() {
+	*x = AddOrdersResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_trader_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -265,13 +486,13 @@ func (x *OrderStatusRequest) Reset() {
 	}
 }
 
-func (x *OrderStatusRequest) String() string {
+func (x *AddOrdersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderStatusRequest) ProtoMessage() {}
+func (*AddOrdersResponse) ProtoMessage() {}
 
-func (x *OrderStatusRequest) ProtoReflect() protoreflect.Message {
+func (x *AddOrdersResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_trader_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -283,30 +504,48 @@ func (x *OrderStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderStatusRequest.ProtoReflect.Descriptor instead.
-func (*OrderStatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddOrdersResponse.ProtoReflect.Descriptor instead.
+func (*AddOrdersResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_trader_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *OrderStatusRequest) GetRefId() int32 {
+func (x *AddOrdersResponse) GetResults() []*AddOrderResult {
 	if x != nil {
-		return x.RefId
+		return x.Results
 	}
-	return 0
+	return nil
 }
 
-type OrderStatusResponse struct {
+type EditOrderRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RefId   int32  `protobuf:"varint,1,opt,name=refId,proto3" json:"refId,omitempty"`
-	OrderId string `protobuf:"bytes,2,opt,name=orderId,proto3" json:"orderId,omitempty"`
-	Status  string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// Types that are assignable to Id:
+	//	*EditOrderRequest_RefId
+	//	*EditOrderRequest_OrderId
+	//	*EditOrderRequest_ClientOrderId
+	Id isEditOrderRequest_Id `protobuf_oneof:"id"`
+	// pair is not needed, the pair of the order is used
+	//
+	// Deprecated: Do not use.
+	Pair string `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	// empty or zero values are not changed
+	Price  string `protobuf:"bytes,6,opt,name=price,proto3" json:"price,omitempty"`
+	Price2 string `protobuf:"bytes,7,opt,name=price2,proto3" json:"price2,omitempty"`
+	Volume string `protobuf:"bytes,8,opt,name=volume,proto3" json:"volume,omitempty"`
+	// doubles are used by old clients, they are read only if the corresponding string is empty
+	//
+	// Deprecated: Do not use.
+	PriceDouble float64 `protobuf:"fixed64,3,opt,name=priceDouble,proto3" json:"priceDouble,omitempty"`
+	// Deprecated: Do not use.
+	Price2Double float64 `protobuf:"fixed64,4,opt,name=price2Double,proto3" json:"price2Double,omitempty"`
+	// Deprecated: Do not use.
+	VolumeDouble float64 `protobuf:"fixed64,5,opt,name=volumeDouble,proto3" json:"volumeDouble,omitempty"`
 }
 
-func (x *OrderStatusResponse) Reset() {
-	*x = OrderStatusResponse{}
+func (x *EditOrderRequest) Reset() {
+	*x = EditOrderRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_trader_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -314,13 +553,13 @@ func (x *OrderStatusResponse) Reset() {
 	}
 }
 
-func (x *OrderStatusResponse) String() string {
+func (x *EditOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderStatusResponse) ProtoMessage() {}
+func (*EditOrderRequest) ProtoMessage() {}
 
-func (x *OrderStatusResponse) ProtoReflect() protoreflect.Message {
+func (x *EditOrderRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_trader_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -332,40 +571,128 @@ func (x *OrderStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderStatusResponse.ProtoReflect.Descriptor instead.
-func (*OrderStatusResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use EditOrderRequest.ProtoReflect.Descriptor instead.
+func (*EditOrderRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_trader_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *OrderStatusResponse) GetRefId() int32 {
-	if x != nil {
+func (m *EditOrderRequest) GetId() isEditOrderRequest_Id {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (x *EditOrderRequest) GetRefId() int32 {
+	if x, ok := x.GetId().(*EditOrderRequest_RefId); ok {
 		return x.RefId
 	}
 	return 0
 }
 
-func (x *OrderStatusResponse) GetOrderId() string {
-	if x != nil {
+func (x *EditOrderRequest) GetOrderId() string {
+	if x, ok := x.GetId().(*EditOrderRequest_OrderId); ok {
 		return x.OrderId
 	}
 	return ""
 }
 
-func (x *OrderStatusResponse) GetStatus() string {
+func (x *EditOrderRequest) GetClientOrderId() string {
+	if x, ok := x.GetId().(*EditOrderRequest_ClientOrderId); ok {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+// Deprecated: Do not use.
+func (x *EditOrderRequest) GetPair() string {
 	if x != nil {
-		return x.Status
+		return x.Pair
 	}
 	return ""
 }
 
-type Empty struct {
+func (x *EditOrderRequest) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *EditOrderRequest) GetPrice2() string {
+	if x != nil {
+		return x.Price2
+	}
+	return ""
+}
+
+func (x *EditOrderRequest) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+// Deprecated: Do not use.
+func (x *EditOrderRequest) GetPriceDouble() float64 {
+	if x != nil {
+		return x.PriceDouble
+	}
+	return 0
+}
+
+// Deprecated: Do not use.
+func (x *EditOrderRequest) GetPrice2Double() float64 {
+	if x != nil {
+		return x.Price2Double
+	}
+	return 0
+}
+
+// Deprecated: Do not use.
+func (x *EditOrderRequest) GetVolumeDouble() float64 {
+	if x != nil {
+		return x.VolumeDouble
+	}
+	return 0
+}
+
+type isEditOrderRequest_Id interface {
+	isEditOrderRequest_Id()
+}
+
+type EditOrderRequest_RefId struct {
+	RefId int32 `protobuf:"varint,1,opt,name=refId,proto3,oneof"`
+}
+
+type EditOrderRequest_OrderId struct {
+	// orderId is id of the order on the exchange
+	OrderId string `protobuf:"bytes,9,opt,name=orderId,proto3,oneof"`
+}
+
+type EditOrderRequest_ClientOrderId struct {
+	ClientOrderId string `protobuf:"bytes,10,opt,name=clientOrderId,proto3,oneof"`
+}
+
+func (*EditOrderRequest_RefId) isEditOrderRequest_Id() {}
+
+func (*EditOrderRequest_OrderId) isEditOrderRequest_Id() {}
+
+func (*EditOrderRequest_ClientOrderId) isEditOrderRequest_Id() {}
+
+type EditOrderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Status          string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	RefId           int32  `protobuf:"varint,2,opt,name=refId,proto3" json:"refId,omitempty"`
+	OrderId         string `protobuf:"bytes,3,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	OriginalOrderId string `protobuf:"bytes,4,opt,name=originalOrderId,proto3" json:"originalOrderId,omitempty"`
 }
 
-func (x *Empty) Reset() {
-	*x = Empty{}
+func (x *EditOrderResponse) Reset() {
+	*x = EditOrderResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_trader_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -373,13 +700,13 @@ func (x *Empty) Reset() {
 	}
 }
 
-func (x *Empty) String() string {
+func (x *EditOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Empty) ProtoMessage() {}
+func (*EditOrderResponse) ProtoMessage() {}
 
-func (x *Empty) ProtoReflect() protoreflect.Message {
+func (x *EditOrderResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_trader_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -391,100 +718,3237 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
-func (*Empty) Descriptor() ([]byte, []int) {
+// Deprecated: Use EditOrderResponse.ProtoReflect.Descriptor instead.
+func (*EditOrderResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_trader_proto_rawDescGZIP(), []int{6}
 }
 
-var File_api_proto_trader_proto protoreflect.FileDescriptor
-
-var file_api_proto_trader_proto_rawDesc = []byte{
-	0x0a, 0x16, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x72, 0x61, 0x64,
-	0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x62, 0x74, 0x68, 0x22, 0x71, 0x0a,
-	0x0f, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x70, 0x61, 0x69, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75,
-	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
-	0x22, 0x5a, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05,
-	0x72, 0x65, 0x66, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x65, 0x66,
-	0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x2a, 0x0a, 0x12,
-	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x22, 0x2d, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63,
-	0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x2a, 0x0a, 0x12, 0x4f, 0x72, 0x64, 0x65, 0x72,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x65,
-	0x66, 0x49, 0x64, 0x22, 0x5d, 0x0a, 0x13, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65,
-	0x66, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64,
-	0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x32, 0x85, 0x02, 0x0a, 0x06,
-	0x54, 0x72, 0x61, 0x64, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x08, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64,
-	0x65, 0x72, 0x12, 0x14, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x41,
-	0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x42, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72,
-	0x12, 0x17, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64,
-	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x62, 0x74, 0x68, 0x2e,
-	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0b, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
-	0x62, 0x74, 0x68, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0c, 0x53, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12, 0x0a, 0x2e, 0x62, 0x74, 0x68, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x4f, 0x72, 0x64, 0x65,
-	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x30, 0x01, 0x42, 0x08, 0x5a, 0x06, 0x2e, 0x2e, 0x2f, 0x62, 0x74, 0x68, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *EditOrderResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
 }
 
-var (
-	file_api_proto_trader_proto_rawDescOnce sync.Once
-	file_api_proto_trader_proto_rawDescData = file_api_proto_trader_proto_rawDesc
-)
-
-func file_api_proto_trader_proto_rawDescGZIP() []byte {
-	file_api_proto_trader_proto_rawDescOnce.Do(func() {
-		file_api_proto_trader_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_proto_trader_proto_rawDescData)
-	})
-	return file_api_proto_trader_proto_rawDescData
+func (x *EditOrderResponse) GetRefId() int32 {
+	if x != nil {
+		return x.RefId
+	}
+	return 0
 }
 
-var file_api_proto_trader_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
-var file_api_proto_trader_proto_goTypes = []interface{}{
-	(*AddOrderRequest)(nil),     // 0: bth.AddOrderRequest
-	(*AddOrderResponse)(nil),    // 1: bth.AddOrderResponse
-	(*CancelOrderRequest)(nil),  // 2: bth.CancelOrderRequest
-	(*CancelOrderResponse)(nil), // 3: bth.CancelOrderResponse
-	(*OrderStatusRequest)(nil),  // 4: bth.OrderStatusRequest
-	(*OrderStatusResponse)(nil), // 5: bth.OrderStatusResponse
-	(*Empty)(nil),               // 6: bth.Empty
-}
+func (x *EditOrderResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *EditOrderResponse) GetOriginalOrderId() string {
+	if x != nil {
+		return x.OriginalOrderId
+	}
+	return ""
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Id:
+	//	*CancelOrderRequest_RefId
+	//	*CancelOrderRequest_OrderId
+	//	*CancelOrderRequest_ClientOrderId
+	Id isCancelOrderRequest_Id `protobuf_oneof:"id"`
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{7}
+}
+
+func (m *CancelOrderRequest) GetId() isCancelOrderRequest_Id {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (x *CancelOrderRequest) GetRefId() int32 {
+	if x, ok := x.GetId().(*CancelOrderRequest_RefId); ok {
+		return x.RefId
+	}
+	return 0
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x, ok := x.GetId().(*CancelOrderRequest_OrderId); ok {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetClientOrderId() string {
+	if x, ok := x.GetId().(*CancelOrderRequest_ClientOrderId); ok {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+type isCancelOrderRequest_Id interface {
+	isCancelOrderRequest_Id()
+}
+
+type CancelOrderRequest_RefId struct {
+	RefId int32 `protobuf:"varint,1,opt,name=refId,proto3,oneof"`
+}
+
+type CancelOrderRequest_OrderId struct {
+	// orderId is id of the order on the exchange
+	OrderId string `protobuf:"bytes,2,opt,name=orderId,proto3,oneof"`
+}
+
+type CancelOrderRequest_ClientOrderId struct {
+	ClientOrderId string `protobuf:"bytes,3,opt,name=clientOrderId,proto3,oneof"`
+}
+
+func (*CancelOrderRequest_RefId) isCancelOrderRequest_Id() {}
+
+func (*CancelOrderRequest_OrderId) isCancelOrderRequest_Id() {}
+
+func (*CancelOrderRequest_ClientOrderId) isCancelOrderRequest_Id() {}
+
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelOrderResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type CancelOrdersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RefIds []int32 `protobuf:"varint,1,rep,packed,name=refIds,proto3" json:"refIds,omitempty"`
+}
+
+func (x *CancelOrdersRequest) Reset() {
+	*x = CancelOrdersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrdersRequest) ProtoMessage() {}
+
+func (x *CancelOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrdersRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancelOrdersRequest) GetRefIds() []int32 {
+	if x != nil {
+		return x.RefIds
+	}
+	return nil
+}
+
+type CancelOrderResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RefId  int32  `protobuf:"varint,1,opt,name=refId,proto3" json:"refId,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Error  string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *CancelOrderResult) Reset() {
+	*x = CancelOrderResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelOrderResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResult) ProtoMessage() {}
+
+func (x *CancelOrderResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResult.ProtoReflect.Descriptor instead.
+func (*CancelOrderResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CancelOrderResult) GetRefId() int32 {
+	if x != nil {
+		return x.RefId
+	}
+	return 0
+}
+
+func (x *CancelOrderResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CancelOrderResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type CancelOrdersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// results are in the same order as refIds in the request
+	Results []*CancelOrderResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *CancelOrdersResponse) Reset() {
+	*x = CancelOrdersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrdersResponse) ProtoMessage() {}
+
+func (x *CancelOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrdersResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelOrdersResponse) GetResults() []*CancelOrderResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type CancelAllRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair string `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+}
+
+func (x *CancelAllRequest) Reset() {
+	*x = CancelAllRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAllRequest) ProtoMessage() {}
+
+func (x *CancelAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAllRequest.ProtoReflect.Descriptor instead.
+func (*CancelAllRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelAllRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+type CancelAllResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Count  int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *CancelAllResponse) Reset() {
+	*x = CancelAllResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelAllResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAllResponse) ProtoMessage() {}
+
+func (x *CancelAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAllResponse.ProtoReflect.Descriptor instead.
+func (*CancelAllResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CancelAllResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CancelAllResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type OrderStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Id:
+	//	*OrderStatusRequest_RefId
+	//	*OrderStatusRequest_OrderId
+	//	*OrderStatusRequest_ClientOrderId
+	Id isOrderStatusRequest_Id `protobuf_oneof:"id"`
+}
+
+func (x *OrderStatusRequest) Reset() {
+	*x = OrderStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusRequest) ProtoMessage() {}
+
+func (x *OrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*OrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{14}
+}
+
+func (m *OrderStatusRequest) GetId() isOrderStatusRequest_Id {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (x *OrderStatusRequest) GetRefId() int32 {
+	if x, ok := x.GetId().(*OrderStatusRequest_RefId); ok {
+		return x.RefId
+	}
+	return 0
+}
+
+func (x *OrderStatusRequest) GetOrderId() string {
+	if x, ok := x.GetId().(*OrderStatusRequest_OrderId); ok {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderStatusRequest) GetClientOrderId() string {
+	if x, ok := x.GetId().(*OrderStatusRequest_ClientOrderId); ok {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+type isOrderStatusRequest_Id interface {
+	isOrderStatusRequest_Id()
+}
+
+type OrderStatusRequest_RefId struct {
+	RefId int32 `protobuf:"varint,1,opt,name=refId,proto3,oneof"`
+}
+
+type OrderStatusRequest_OrderId struct {
+	// orderId is id of the order on the exchange
+	OrderId string `protobuf:"bytes,2,opt,name=orderId,proto3,oneof"`
+}
+
+type OrderStatusRequest_ClientOrderId struct {
+	ClientOrderId string `protobuf:"bytes,3,opt,name=clientOrderId,proto3,oneof"`
+}
+
+func (*OrderStatusRequest_RefId) isOrderStatusRequest_Id() {}
+
+func (*OrderStatusRequest_OrderId) isOrderStatusRequest_Id() {}
+
+func (*OrderStatusRequest_ClientOrderId) isOrderStatusRequest_Id() {}
+
+type OrderStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RefId   int32  `protobuf:"varint,1,opt,name=refId,proto3" json:"refId,omitempty"`
+	OrderId string `protobuf:"bytes,2,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	Status  string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Pair    string `protobuf:"bytes,4,opt,name=pair,proto3" json:"pair,omitempty"`
+	// side is buy or sell
+	Side       string `protobuf:"bytes,5,opt,name=side,proto3" json:"side,omitempty"`
+	OrderType  string `protobuf:"bytes,6,opt,name=orderType,proto3" json:"orderType,omitempty"`
+	Volume     string `protobuf:"bytes,30,opt,name=volume,proto3" json:"volume,omitempty"`
+	VolumeExec string `protobuf:"bytes,31,opt,name=volumeExec,proto3" json:"volumeExec,omitempty"`
+	Cost       string `protobuf:"bytes,32,opt,name=cost,proto3" json:"cost,omitempty"`
+	Fee        string `protobuf:"bytes,33,opt,name=fee,proto3" json:"fee,omitempty"`
+	AvgPrice   string `protobuf:"bytes,34,opt,name=avgPrice,proto3" json:"avgPrice,omitempty"`
+	Price      string `protobuf:"bytes,35,opt,name=price,proto3" json:"price,omitempty"`
+	Price2     string `protobuf:"bytes,36,opt,name=price2,proto3" json:"price2,omitempty"`
+	LimitPrice string `protobuf:"bytes,37,opt,name=limitPrice,proto3" json:"limitPrice,omitempty"`
+	StopPrice  string `protobuf:"bytes,38,opt,name=stopPrice,proto3" json:"stopPrice,omitempty"`
+	// time values are unix time in milliseconds, 0 if unknown
+	OpenTime      int64  `protobuf:"varint,16,opt,name=openTime,proto3" json:"openTime,omitempty"`
+	CloseTime     int64  `protobuf:"varint,17,opt,name=closeTime,proto3" json:"closeTime,omitempty"`
+	LastUpdated   int64  `protobuf:"varint,18,opt,name=lastUpdated,proto3" json:"lastUpdated,omitempty"`
+	CancelReason  string `protobuf:"bytes,19,opt,name=cancelReason,proto3" json:"cancelReason,omitempty"`
+	Misc          string `protobuf:"bytes,20,opt,name=misc,proto3" json:"misc,omitempty"`
+	Oflags        string `protobuf:"bytes,21,opt,name=oflags,proto3" json:"oflags,omitempty"`
+	Error         string `protobuf:"bytes,22,opt,name=error,proto3" json:"error,omitempty"`
+	ReplacedId    string `protobuf:"bytes,23,opt,name=replacedId,proto3" json:"replacedId,omitempty"`
+	ClientOrderId string `protobuf:"bytes,24,opt,name=clientOrderId,proto3" json:"clientOrderId,omitempty"`
+	Tag           string `protobuf:"bytes,25,opt,name=tag,proto3" json:"tag,omitempty"`
+	// createdAt and updatedAt are times when the service received the order and its last update, unix milliseconds,
+	// they are set only by ListOrders
+	CreatedAt int64 `protobuf:"varint,26,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt int64 `protobuf:"varint,27,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	// sequence numbers updates in StreamOrders, orders of the snapshot have sequence of the last update before it
+	Sequence uint64 `protobuf:"varint,28,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// snapshot is true for orders sent at the start of StreamOrders
+	Snapshot bool `protobuf:"varint,29,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *OrderStatusResponse) Reset() {
+	*x = OrderStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusResponse) ProtoMessage() {}
+
+func (x *OrderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusResponse.ProtoReflect.Descriptor instead.
+func (*OrderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OrderStatusResponse) GetRefId() int32 {
+	if x != nil {
+		return x.RefId
+	}
+	return 0
+}
+
+func (x *OrderStatusResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetVolumeExec() string {
+	if x != nil {
+		return x.VolumeExec
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetCost() string {
+	if x != nil {
+		return x.Cost
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetFee() string {
+	if x != nil {
+		return x.Fee
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetAvgPrice() string {
+	if x != nil {
+		return x.AvgPrice
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetPrice2() string {
+	if x != nil {
+		return x.Price2
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetLimitPrice() string {
+	if x != nil {
+		return x.LimitPrice
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetStopPrice() string {
+	if x != nil {
+		return x.StopPrice
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetOpenTime() int64 {
+	if x != nil {
+		return x.OpenTime
+	}
+	return 0
+}
+
+func (x *OrderStatusResponse) GetCloseTime() int64 {
+	if x != nil {
+		return x.CloseTime
+	}
+	return 0
+}
+
+func (x *OrderStatusResponse) GetLastUpdated() int64 {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return 0
+}
+
+func (x *OrderStatusResponse) GetCancelReason() string {
+	if x != nil {
+		return x.CancelReason
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetMisc() string {
+	if x != nil {
+		return x.Misc
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetOflags() string {
+	if x != nil {
+		return x.Oflags
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetReplacedId() string {
+	if x != nil {
+		return x.ReplacedId
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *OrderStatusResponse) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *OrderStatusResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *OrderStatusResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *OrderStatusResponse) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+type StreamOrdersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// refIds, pairs and statuses limit orders in the stream, empty filters match all orders
+	RefIds   []int32  `protobuf:"varint,1,rep,packed,name=refIds,proto3" json:"refIds,omitempty"`
+	Pairs    []string `protobuf:"bytes,2,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	Statuses []string `protobuf:"bytes,3,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	// fromSequence resumes the stream after the update with this sequence instead of sending a snapshot
+	FromSequence uint64 `protobuf:"varint,4,opt,name=fromSequence,proto3" json:"fromSequence,omitempty"`
+}
+
+func (x *StreamOrdersRequest) Reset() {
+	*x = StreamOrdersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamOrdersRequest) ProtoMessage() {}
+
+func (x *StreamOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamOrdersRequest.ProtoReflect.Descriptor instead.
+func (*StreamOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StreamOrdersRequest) GetRefIds() []int32 {
+	if x != nil {
+		return x.RefIds
+	}
+	return nil
+}
+
+func (x *StreamOrdersRequest) GetPairs() []string {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+func (x *StreamOrdersRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *StreamOrdersRequest) GetFromSequence() uint64 {
+	if x != nil {
+		return x.FromSequence
+	}
+	return 0
+}
+
+type ListOrdersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// statuses limits orders to these statuses, all orders are listed if empty
+	Statuses []string `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	Pair     string   `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	// side is buy or sell
+	Side string `protobuf:"bytes,3,opt,name=side,proto3" json:"side,omitempty"`
+	Tag  string `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`
+	// from and to limit time of creation or the last update according to sortBy, unix milliseconds, 0 is not limited
+	From int64 `protobuf:"varint,5,opt,name=from,proto3" json:"from,omitempty"`
+	To   int64 `protobuf:"varint,6,opt,name=to,proto3" json:"to,omitempty"`
+	// sortBy is "created" (default) or "updated"
+	SortBy     string `protobuf:"bytes,7,opt,name=sortBy,proto3" json:"sortBy,omitempty"`
+	Descending bool   `protobuf:"varint,8,opt,name=descending,proto3" json:"descending,omitempty"`
+	// pageSize is maximum number of orders in the response, 100 by default, at most 1000
+	PageSize int32 `protobuf:"varint,9,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// pageToken is nextPageToken from the previous response
+	PageToken string `protobuf:"bytes,10,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListOrdersRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Orders []*OrderStatusResponse `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// nextPageToken is empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*OrderStatusResponse {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type OrderTransition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId string `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	From    string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To      string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// time is unix time in milliseconds
+	Time int64 `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *OrderTransition) Reset() {
+	*x = OrderTransition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderTransition) ProtoMessage() {}
+
+func (x *OrderTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderTransition.ProtoReflect.Descriptor instead.
+func (*OrderTransition) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OrderTransition) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderTransition) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *OrderTransition) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *OrderTransition) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type OrderHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RefId       int32              `protobuf:"varint,1,opt,name=refId,proto3" json:"refId,omitempty"`
+	Transitions []*OrderTransition `protobuf:"bytes,2,rep,name=transitions,proto3" json:"transitions,omitempty"`
+}
+
+func (x *OrderHistoryResponse) Reset() {
+	*x = OrderHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderHistoryResponse) ProtoMessage() {}
+
+func (x *OrderHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderHistoryResponse.ProtoReflect.Descriptor instead.
+func (*OrderHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *OrderHistoryResponse) GetRefId() int32 {
+	if x != nil {
+		return x.RefId
+	}
+	return 0
+}
+
+func (x *OrderHistoryResponse) GetTransitions() []*OrderTransition {
+	if x != nil {
+		return x.Transitions
+	}
+	return nil
+}
+
+type StreamTradesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair  string `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	RefId int32  `protobuf:"varint,2,opt,name=refId,proto3" json:"refId,omitempty"`
+}
+
+func (x *StreamTradesRequest) Reset() {
+	*x = StreamTradesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamTradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTradesRequest) ProtoMessage() {}
+
+func (x *StreamTradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTradesRequest.ProtoReflect.Descriptor instead.
+func (*StreamTradesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StreamTradesRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *StreamTradesRequest) GetRefId() int32 {
+	if x != nil {
+		return x.RefId
+	}
+	return 0
+}
+
+type Trade struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TradeId    string `protobuf:"bytes,1,opt,name=tradeId,proto3" json:"tradeId,omitempty"`
+	OrderId    string `protobuf:"bytes,2,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	PositionId string `protobuf:"bytes,3,opt,name=positionId,proto3" json:"positionId,omitempty"`
+	RefId      int32  `protobuf:"varint,4,opt,name=refId,proto3" json:"refId,omitempty"`
+	Pair       string `protobuf:"bytes,5,opt,name=pair,proto3" json:"pair,omitempty"`
+	// type is buy or sell
+	Type      string `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	OrderType string `protobuf:"bytes,7,opt,name=orderType,proto3" json:"orderType,omitempty"`
+	Price     string `protobuf:"bytes,14,opt,name=price,proto3" json:"price,omitempty"`
+	Volume    string `protobuf:"bytes,15,opt,name=volume,proto3" json:"volume,omitempty"`
+	Cost      string `protobuf:"bytes,16,opt,name=cost,proto3" json:"cost,omitempty"`
+	Fee       string `protobuf:"bytes,17,opt,name=fee,proto3" json:"fee,omitempty"`
+	Margin    string `protobuf:"bytes,18,opt,name=margin,proto3" json:"margin,omitempty"`
+	// time in unix milliseconds
+	Time int64 `protobuf:"varint,13,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Trade) GetTradeId() string {
+	if x != nil {
+		return x.TradeId
+	}
+	return ""
+}
+
+func (x *Trade) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Trade) GetPositionId() string {
+	if x != nil {
+		return x.PositionId
+	}
+	return ""
+}
+
+func (x *Trade) GetRefId() int32 {
+	if x != nil {
+		return x.RefId
+	}
+	return 0
+}
+
+func (x *Trade) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *Trade) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Trade) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *Trade) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Trade) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *Trade) GetCost() string {
+	if x != nil {
+		return x.Cost
+	}
+	return ""
+}
+
+func (x *Trade) GetFee() string {
+	if x != nil {
+		return x.Fee
+	}
+	return ""
+}
+
+func (x *Trade) GetMargin() string {
+	if x != nil {
+		return x.Margin
+	}
+	return ""
+}
+
+func (x *Trade) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type StreamMarketRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// pairs in format of websocket API, e.g. XBT/EUR, at least one pair is required
+	Pairs []string `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+}
+
+func (x *StreamMarketRequest) Reset() {
+	*x = StreamMarketRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamMarketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamMarketRequest) ProtoMessage() {}
+
+func (x *StreamMarketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamMarketRequest.ProtoReflect.Descriptor instead.
+func (*StreamMarketRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StreamMarketRequest) GetPairs() []string {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+type StreamCandlesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pairs []string `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	// interval is length of candles in minutes: 1 (default), 5, 15, 30, 60, 240, 1440, 10080 or 21600
+	Interval int32 `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *StreamCandlesRequest) Reset() {
+	*x = StreamCandlesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamCandlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamCandlesRequest) ProtoMessage() {}
+
+func (x *StreamCandlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamCandlesRequest.ProtoReflect.Descriptor instead.
+func (*StreamCandlesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StreamCandlesRequest) GetPairs() []string {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+func (x *StreamCandlesRequest) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+// Ticker contains values of the last 24 hours
+type Ticker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair       string `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Ask        string `protobuf:"bytes,14,opt,name=ask,proto3" json:"ask,omitempty"`
+	AskVolume  string `protobuf:"bytes,15,opt,name=askVolume,proto3" json:"askVolume,omitempty"`
+	Bid        string `protobuf:"bytes,16,opt,name=bid,proto3" json:"bid,omitempty"`
+	BidVolume  string `protobuf:"bytes,17,opt,name=bidVolume,proto3" json:"bidVolume,omitempty"`
+	Last       string `protobuf:"bytes,18,opt,name=last,proto3" json:"last,omitempty"`
+	LastVolume string `protobuf:"bytes,19,opt,name=lastVolume,proto3" json:"lastVolume,omitempty"`
+	Volume     string `protobuf:"bytes,20,opt,name=volume,proto3" json:"volume,omitempty"`
+	Vwap       string `protobuf:"bytes,21,opt,name=vwap,proto3" json:"vwap,omitempty"`
+	Trades     int32  `protobuf:"varint,10,opt,name=trades,proto3" json:"trades,omitempty"`
+	Low        string `protobuf:"bytes,22,opt,name=low,proto3" json:"low,omitempty"`
+	High       string `protobuf:"bytes,23,opt,name=high,proto3" json:"high,omitempty"`
+	Open       string `protobuf:"bytes,24,opt,name=open,proto3" json:"open,omitempty"`
+}
+
+func (x *Ticker) Reset() {
+	*x = Ticker{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ticker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ticker) ProtoMessage() {}
+
+func (x *Ticker) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ticker.ProtoReflect.Descriptor instead.
+func (*Ticker) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Ticker) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *Ticker) GetAsk() string {
+	if x != nil {
+		return x.Ask
+	}
+	return ""
+}
+
+func (x *Ticker) GetAskVolume() string {
+	if x != nil {
+		return x.AskVolume
+	}
+	return ""
+}
+
+func (x *Ticker) GetBid() string {
+	if x != nil {
+		return x.Bid
+	}
+	return ""
+}
+
+func (x *Ticker) GetBidVolume() string {
+	if x != nil {
+		return x.BidVolume
+	}
+	return ""
+}
+
+func (x *Ticker) GetLast() string {
+	if x != nil {
+		return x.Last
+	}
+	return ""
+}
+
+func (x *Ticker) GetLastVolume() string {
+	if x != nil {
+		return x.LastVolume
+	}
+	return ""
+}
+
+func (x *Ticker) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *Ticker) GetVwap() string {
+	if x != nil {
+		return x.Vwap
+	}
+	return ""
+}
+
+func (x *Ticker) GetTrades() int32 {
+	if x != nil {
+		return x.Trades
+	}
+	return 0
+}
+
+func (x *Ticker) GetLow() string {
+	if x != nil {
+		return x.Low
+	}
+	return ""
+}
+
+func (x *Ticker) GetHigh() string {
+	if x != nil {
+		return x.High
+	}
+	return ""
+}
+
+func (x *Ticker) GetOpen() string {
+	if x != nil {
+		return x.Open
+	}
+	return ""
+}
+
+type Spread struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair      string `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Bid       string `protobuf:"bytes,7,opt,name=bid,proto3" json:"bid,omitempty"`
+	Ask       string `protobuf:"bytes,8,opt,name=ask,proto3" json:"ask,omitempty"`
+	BidVolume string `protobuf:"bytes,9,opt,name=bidVolume,proto3" json:"bidVolume,omitempty"`
+	AskVolume string `protobuf:"bytes,10,opt,name=askVolume,proto3" json:"askVolume,omitempty"`
+	// time in unix milliseconds
+	Time int64 `protobuf:"varint,6,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *Spread) Reset() {
+	*x = Spread{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Spread) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Spread) ProtoMessage() {}
+
+func (x *Spread) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Spread.ProtoReflect.Descriptor instead.
+func (*Spread) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Spread) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *Spread) GetBid() string {
+	if x != nil {
+		return x.Bid
+	}
+	return ""
+}
+
+func (x *Spread) GetAsk() string {
+	if x != nil {
+		return x.Ask
+	}
+	return ""
+}
+
+func (x *Spread) GetBidVolume() string {
+	if x != nil {
+		return x.BidVolume
+	}
+	return ""
+}
+
+func (x *Spread) GetAskVolume() string {
+	if x != nil {
+		return x.AskVolume
+	}
+	return ""
+}
+
+func (x *Spread) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type PublicTrade struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair   string `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Price  string `protobuf:"bytes,8,opt,name=price,proto3" json:"price,omitempty"`
+	Volume string `protobuf:"bytes,9,opt,name=volume,proto3" json:"volume,omitempty"`
+	// time in unix milliseconds
+	Time int64 `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	// side is buy or sell
+	Side string `protobuf:"bytes,5,opt,name=side,proto3" json:"side,omitempty"`
+	// orderType is market or limit
+	OrderType string `protobuf:"bytes,6,opt,name=orderType,proto3" json:"orderType,omitempty"`
+	Misc      string `protobuf:"bytes,7,opt,name=misc,proto3" json:"misc,omitempty"`
+}
+
+func (x *PublicTrade) Reset() {
+	*x = PublicTrade{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublicTrade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicTrade) ProtoMessage() {}
+
+func (x *PublicTrade) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicTrade.ProtoReflect.Descriptor instead.
+func (*PublicTrade) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PublicTrade) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *PublicTrade) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *PublicTrade) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *PublicTrade) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *PublicTrade) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *PublicTrade) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *PublicTrade) GetMisc() string {
+	if x != nil {
+		return x.Misc
+	}
+	return ""
+}
+
+type Candle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair     string `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Interval int32  `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	// start, end and updated are times in unix milliseconds
+	Start   int64  `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	End     int64  `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+	Updated int64  `protobuf:"varint,5,opt,name=updated,proto3" json:"updated,omitempty"`
+	Open    string `protobuf:"bytes,13,opt,name=open,proto3" json:"open,omitempty"`
+	High    string `protobuf:"bytes,14,opt,name=high,proto3" json:"high,omitempty"`
+	Low     string `protobuf:"bytes,15,opt,name=low,proto3" json:"low,omitempty"`
+	Close   string `protobuf:"bytes,16,opt,name=close,proto3" json:"close,omitempty"`
+	Vwap    string `protobuf:"bytes,17,opt,name=vwap,proto3" json:"vwap,omitempty"`
+	Volume  string `protobuf:"bytes,18,opt,name=volume,proto3" json:"volume,omitempty"`
+	Trades  int32  `protobuf:"varint,12,opt,name=trades,proto3" json:"trades,omitempty"`
+}
+
+func (x *Candle) Reset() {
+	*x = Candle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Candle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candle) ProtoMessage() {}
+
+func (x *Candle) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candle.ProtoReflect.Descriptor instead.
+func (*Candle) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Candle) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *Candle) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *Candle) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Candle) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *Candle) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *Candle) GetOpen() string {
+	if x != nil {
+		return x.Open
+	}
+	return ""
+}
+
+func (x *Candle) GetHigh() string {
+	if x != nil {
+		return x.High
+	}
+	return ""
+}
+
+func (x *Candle) GetLow() string {
+	if x != nil {
+		return x.Low
+	}
+	return ""
+}
+
+func (x *Candle) GetClose() string {
+	if x != nil {
+		return x.Close
+	}
+	return ""
+}
+
+func (x *Candle) GetVwap() string {
+	if x != nil {
+		return x.Vwap
+	}
+	return ""
+}
+
+func (x *Candle) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *Candle) GetTrades() int32 {
+	if x != nil {
+		return x.Trades
+	}
+	return 0
+}
+
+type OrderBookRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// pair in format of websocket API, e.g. XBT/EUR
+	Pair string `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	// depth is number of price levels of each side: 10 (default), 25, 100, 500 or 1000
+	Depth int32 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+}
+
+func (x *OrderBookRequest) Reset() {
+	*x = OrderBookRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBookRequest) ProtoMessage() {}
+
+func (x *OrderBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBookRequest.ProtoReflect.Descriptor instead.
+func (*OrderBookRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *OrderBookRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *OrderBookRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type OrderBookLevel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Price  string `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	Volume string `protobuf:"bytes,5,opt,name=volume,proto3" json:"volume,omitempty"`
+	// time in unix milliseconds
+	Time int64 `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *OrderBookLevel) Reset() {
+	*x = OrderBookLevel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderBookLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBookLevel) ProtoMessage() {}
+
+func (x *OrderBookLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBookLevel.ProtoReflect.Descriptor instead.
+func (*OrderBookLevel) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *OrderBookLevel) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *OrderBookLevel) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *OrderBookLevel) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type OrderBook struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair  string `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Depth int32  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	// asks are sorted from the lowest price, bids are sorted from the highest price
+	Asks []*OrderBookLevel `protobuf:"bytes,3,rep,name=asks,proto3" json:"asks,omitempty"`
+	Bids []*OrderBookLevel `protobuf:"bytes,4,rep,name=bids,proto3" json:"bids,omitempty"`
+	// updated is time of the last change in unix milliseconds
+	Updated int64 `protobuf:"varint,5,opt,name=updated,proto3" json:"updated,omitempty"`
+	// sequence increases with every change of the book
+	Sequence uint64 `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *OrderBook) Reset() {
+	*x = OrderBook{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderBook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBook) ProtoMessage() {}
+
+func (x *OrderBook) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBook.ProtoReflect.Descriptor instead.
+func (*OrderBook) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *OrderBook) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *OrderBook) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *OrderBook) GetAsks() []*OrderBookLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *OrderBook) GetBids() []*OrderBookLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *OrderBook) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *OrderBook) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type BalancesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// assets filters balances by any name of the asset, e.g. XXBT, XBT or BTC, all balances are returned if empty
+	Assets []string `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+}
+
+func (x *BalancesRequest) Reset() {
+	*x = BalancesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BalancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalancesRequest) ProtoMessage() {}
+
+func (x *BalancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalancesRequest.ProtoReflect.Descriptor instead.
+func (*BalancesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *BalancesRequest) GetAssets() []string {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+type Balance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// asset is the name of the asset used by REST API of the exchange, e.g. XXBT or ZEUR
+	Asset string `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	// total is the balance on the exchange
+	Total string `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
+	// reserved is the part of the balance held by open orders placed through the service
+	Reserved string `protobuf:"bytes,3,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	// available is total without reserved funds
+	Available string `protobuf:"bytes,4,opt,name=available,proto3" json:"available,omitempty"`
+}
+
+func (x *Balance) Reset() {
+	*x = Balance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Balance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Balance) ProtoMessage() {}
+
+func (x *Balance) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Balance.ProtoReflect.Descriptor instead.
+func (*Balance) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Balance) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *Balance) GetTotal() string {
+	if x != nil {
+		return x.Total
+	}
+	return ""
+}
+
+func (x *Balance) GetReserved() string {
+	if x != nil {
+		return x.Reserved
+	}
+	return ""
+}
+
+func (x *Balance) GetAvailable() string {
+	if x != nil {
+		return x.Available
+	}
+	return ""
+}
+
+type BalancesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Balances []*Balance `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	// updated is time when balances were loaded from the exchange in unix milliseconds
+	Updated int64 `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	// sequence increases with every change of balances or reserved funds
+	Sequence uint64 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *BalancesResponse) Reset() {
+	*x = BalancesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BalancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalancesResponse) ProtoMessage() {}
+
+func (x *BalancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalancesResponse.ProtoReflect.Descriptor instead.
+func (*BalancesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *BalancesResponse) GetBalances() []*Balance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+func (x *BalancesResponse) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *BalancesResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type TradeBalanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// asset is the asset of values, e.g. ZEUR, the exchange uses ZUSD by default
+	Asset string `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+}
+
+func (x *TradeBalanceRequest) Reset() {
+	*x = TradeBalanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TradeBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradeBalanceRequest) ProtoMessage() {}
+
+func (x *TradeBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradeBalanceRequest.ProtoReflect.Descriptor instead.
+func (*TradeBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TradeBalanceRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+// TradeBalanceResponse contains values in the asset of the request
+type TradeBalanceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// equivalentBalance is combined balance of all currencies
+	EquivalentBalance string `protobuf:"bytes,1,opt,name=equivalentBalance,proto3" json:"equivalentBalance,omitempty"`
+	// tradeBalance is combined balance of all equity currencies
+	TradeBalance string `protobuf:"bytes,2,opt,name=tradeBalance,proto3" json:"tradeBalance,omitempty"`
+	Margin       string `protobuf:"bytes,3,opt,name=margin,proto3" json:"margin,omitempty"`
+	// unrealizedPnl is unrealized net profit/loss of open positions
+	UnrealizedPnl string `protobuf:"bytes,4,opt,name=unrealizedPnl,proto3" json:"unrealizedPnl,omitempty"`
+	CostBasis     string `protobuf:"bytes,5,opt,name=costBasis,proto3" json:"costBasis,omitempty"`
+	// valuation is current floating valuation of open positions
+	Valuation string `protobuf:"bytes,6,opt,name=valuation,proto3" json:"valuation,omitempty"`
+	// equity is trade balance plus unrealized net profit/loss
+	Equity string `protobuf:"bytes,7,opt,name=equity,proto3" json:"equity,omitempty"`
+	// freeMargin is margin available to open new positions
+	FreeMargin string `protobuf:"bytes,8,opt,name=freeMargin,proto3" json:"freeMargin,omitempty"`
+	// marginLevel is equity divided by initial margin in percents
+	MarginLevel string `protobuf:"bytes,9,opt,name=marginLevel,proto3" json:"marginLevel,omitempty"`
+	// unexecuted is value of unfilled and partially filled orders
+	Unexecuted string `protobuf:"bytes,10,opt,name=unexecuted,proto3" json:"unexecuted,omitempty"`
+}
+
+func (x *TradeBalanceResponse) Reset() {
+	*x = TradeBalanceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TradeBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradeBalanceResponse) ProtoMessage() {}
+
+func (x *TradeBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradeBalanceResponse.ProtoReflect.Descriptor instead.
+func (*TradeBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TradeBalanceResponse) GetEquivalentBalance() string {
+	if x != nil {
+		return x.EquivalentBalance
+	}
+	return ""
+}
+
+func (x *TradeBalanceResponse) GetTradeBalance() string {
+	if x != nil {
+		return x.TradeBalance
+	}
+	return ""
+}
+
+func (x *TradeBalanceResponse) GetMargin() string {
+	if x != nil {
+		return x.Margin
+	}
+	return ""
+}
+
+func (x *TradeBalanceResponse) GetUnrealizedPnl() string {
+	if x != nil {
+		return x.UnrealizedPnl
+	}
+	return ""
+}
+
+func (x *TradeBalanceResponse) GetCostBasis() string {
+	if x != nil {
+		return x.CostBasis
+	}
+	return ""
+}
+
+func (x *TradeBalanceResponse) GetValuation() string {
+	if x != nil {
+		return x.Valuation
+	}
+	return ""
+}
+
+func (x *TradeBalanceResponse) GetEquity() string {
+	if x != nil {
+		return x.Equity
+	}
+	return ""
+}
+
+func (x *TradeBalanceResponse) GetFreeMargin() string {
+	if x != nil {
+		return x.FreeMargin
+	}
+	return ""
+}
+
+func (x *TradeBalanceResponse) GetMarginLevel() string {
+	if x != nil {
+		return x.MarginLevel
+	}
+	return ""
+}
+
+func (x *TradeBalanceResponse) GetUnexecuted() string {
+	if x != nil {
+		return x.Unexecuted
+	}
+	return ""
+}
+
+type HealthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// connection is state of the connection: connected, reconnecting, failed or disconnected
+	Connection string `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`
+	// lastSeen is time of the last message from the exchange in unix milliseconds
+	LastSeen int64 `protobuf:"varint,2,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	// latency is round-trip time of the last ping in milliseconds
+	Latency int64 `protobuf:"varint,3,opt,name=latency,proto3" json:"latency,omitempty"`
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *HealthResponse) GetConnection() string {
+	if x != nil {
+		return x.Connection
+	}
+	return ""
+}
+
+func (x *HealthResponse) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *HealthResponse) GetLatency() int64 {
+	if x != nil {
+		return x.Latency
+	}
+	return 0
+}
+
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_trader_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trader_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_api_proto_trader_proto_rawDescGZIP(), []int{38}
+}
+
+var File_api_proto_trader_proto protoreflect.FileDescriptor
+
+var file_api_proto_trader_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x72, 0x61, 0x64,
+	0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x62, 0x74, 0x68, 0x22, 0x93, 0x06,
+	0x0a, 0x0f, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x63, 0x65, 0x44, 0x6f, 0x75, 0x62,
+	0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0b, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x12, 0x26, 0x0a, 0x0c, 0x76, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x42,
+	0x02, 0x18, 0x01, 0x52, 0x0c, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x44, 0x6f, 0x75, 0x62, 0x6c,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x32, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x32, 0x12, 0x26, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x63, 0x65, 0x32, 0x44,
+	0x6f, 0x75, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x42, 0x02, 0x18, 0x01, 0x52,
+	0x0c, 0x70, 0x72, 0x69, 0x63, 0x65, 0x32, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x70, 0x72, 0x69, 0x63, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a,
+	0x0c, 0x70, 0x72, 0x69, 0x63, 0x65, 0x32, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x69, 0x63, 0x65, 0x32, 0x4f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x72, 0x69, 0x63, 0x65, 0x32, 0x50, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x70, 0x72, 0x69, 0x63, 0x65, 0x32,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x65, 0x76, 0x65, 0x72,
+	0x61, 0x67, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x65, 0x76, 0x65, 0x72,
+	0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x0d, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x74,
+	0x69, 0x6d, 0x65, 0x49, 0x6e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x49, 0x6e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x64, 0x75, 0x63,
+	0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x64,
+	0x75, 0x63, 0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x49, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x69, 0x64, 0x65,
+	0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x74, 0x61, 0x67, 0x22, 0x5a, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x72, 0x65, 0x66, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22,
+	0x40, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x06, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x73, 0x22, 0x6e, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72,
+	0x65, 0x66, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x65, 0x66, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x22, 0x42, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x41, 0x64,
+	0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xc8, 0x02, 0x0a, 0x10, 0x45, 0x64, 0x69, 0x74, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x05, 0x72, 0x65,
+	0x66, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x05, 0x72, 0x65, 0x66,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x26,
+	0x0a, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x32, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x32, 0x12, 0x16, 0x0a, 0x06,
+	0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x63, 0x65, 0x44, 0x6f, 0x75,
+	0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0b, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x12, 0x26, 0x0a, 0x0c, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x32, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
+	0x42, 0x02, 0x18, 0x01, 0x52, 0x0c, 0x70, 0x72, 0x69, 0x63, 0x65, 0x32, 0x44, 0x6f, 0x75, 0x62,
+	0x6c, 0x65, 0x12, 0x26, 0x0a, 0x0c, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x44, 0x6f, 0x75, 0x62,
+	0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0c, 0x76, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x42, 0x04, 0x0a, 0x02, 0x69, 0x64,
+	0x22, 0x85, 0x01, 0x0a, 0x11, 0x45, 0x64, 0x69, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72,
+	0x65, 0x66, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x28,
+	0x0a, 0x0f, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61,
+	0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x76, 0x0a, 0x12, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52,
+	0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x26, 0x0a, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0d, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x42, 0x04, 0x0a, 0x02, 0x69, 0x64,
+	0x22, 0x2d, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22,
+	0x2d, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x66, 0x49, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x06, 0x72, 0x65, 0x66, 0x49, 0x64, 0x73, 0x22, 0x57,
+	0x0a, 0x11, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x48, 0x0a, 0x14, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x30, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x22, 0x26, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x6c, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x22, 0x41, 0x0a, 0x11, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x76, 0x0a, 0x12,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x48, 0x00, 0x52, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x07, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x42, 0x04,
+	0x0a, 0x02, 0x69, 0x64, 0x22, 0x9d, 0x06, 0x0a, 0x13, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x72, 0x65, 0x66, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x65, 0x66,
+	0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x64, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x45, 0x78, 0x65, 0x63,
+	0x18, 0x1f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x45, 0x78,
+	0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x20, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x21, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x66, 0x65, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x76, 0x67, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x18, 0x22, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x76, 0x67, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x23, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x32, 0x18, 0x24, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x32, 0x12, 0x1e, 0x0a, 0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x25, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x6f, 0x70, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18,
+	0x26, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x6f, 0x70, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x61,
+	0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x12, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c,
+	0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x6d, 0x69, 0x73, 0x63, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6d, 0x69, 0x73, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x15,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x49, 0x64,
+	0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x64,
+	0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18,
+	0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x1d,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4a, 0x04,
+	0x08, 0x07, 0x10, 0x10, 0x22, 0x83, 0x01, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x66, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x06, 0x72, 0x65,
+	0x66, 0x49, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x66, 0x72,
+	0x6f, 0x6d, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0xff, 0x01, 0x0a, 0x11, 0x4c,
+	0x69, 0x73, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x73, 0x69, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f,
+	0x72, 0x74, 0x42, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x72, 0x74,
+	0x42, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x6c, 0x0a, 0x12,
+	0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x30, 0x0a, 0x06, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x06, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78,
+	0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x63, 0x0a, 0x0f, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a,
+	0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22,
+	0x64, 0x0a, 0x14, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x12, 0x36, 0x0a,
+	0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x3f, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54,
+	0x72, 0x61, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72,
+	0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x22, 0xbd, 0x02, 0x0a, 0x05, 0x54, 0x72, 0x61, 0x64, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x65, 0x66, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x69, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f,
+	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x66, 0x65, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x12,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x4a, 0x04, 0x08, 0x08, 0x10, 0x0d, 0x22, 0x2b, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61,
+	0x69, 0x72, 0x73, 0x22, 0x48, 0x0a, 0x14, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x61, 0x6e,
+	0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70,
+	0x61, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x69, 0x72,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0xba, 0x02,
+	0x0a, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x10, 0x0a, 0x03,
+	0x61, 0x73, 0x6b, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x61, 0x73, 0x6b, 0x12, 0x1c,
+	0x0a, 0x09, 0x61, 0x73, 0x6b, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x61, 0x73, 0x6b, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x62, 0x69, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x62, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x62, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6c, 0x61, 0x73, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x61, 0x73, 0x74,
+	0x12, 0x1e, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x77, 0x61, 0x70,
+	0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x77, 0x61, 0x70, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x72, 0x61, 0x64, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x74, 0x72,
+	0x61, 0x64, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x77, 0x18, 0x16, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6c, 0x6f, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x67, 0x68, 0x18, 0x17,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x70,
+	0x65, 0x6e, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x4a, 0x04,
+	0x08, 0x02, 0x10, 0x0a, 0x4a, 0x04, 0x08, 0x0b, 0x10, 0x0e, 0x22, 0x96, 0x01, 0x0a, 0x06, 0x53,
+	0x70, 0x72, 0x65, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x61,
+	0x73, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x61, 0x73, 0x6b, 0x12, 0x1c, 0x0a,
+	0x09, 0x62, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x62, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61,
+	0x73, 0x6b, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x61, 0x73, 0x6b, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x4a, 0x04, 0x08,
+	0x02, 0x10, 0x06, 0x22, 0xb5, 0x01, 0x0a, 0x0b, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x54, 0x72,
+	0x61, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x64,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x64, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d,
+	0x69, 0x73, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x69, 0x73, 0x63, 0x4a,
+	0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x22, 0x94, 0x02, 0x0a, 0x06,
+	0x43, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x69, 0x67, 0x68, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68,
+	0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x77, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6c,
+	0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x77, 0x61, 0x70,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x77, 0x61, 0x70, 0x12, 0x16, 0x0a, 0x06,
+	0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x72, 0x61, 0x64, 0x65, 0x73, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x74, 0x72, 0x61, 0x64, 0x65, 0x73, 0x4a, 0x04, 0x08, 0x06,
+	0x10, 0x0c, 0x22, 0x3c, 0x0a, 0x10, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65,
+	0x70, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65, 0x70, 0x74, 0x68,
+	0x22, 0x5e, 0x0a, 0x0e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03,
+	0x22, 0xbd, 0x01, 0x0a, 0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61,
+	0x69, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x12, 0x27, 0x0a, 0x04, 0x61, 0x73, 0x6b, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x04, 0x61, 0x73, 0x6b,
+	0x73, 0x12, 0x27, 0x0a, 0x04, 0x62, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x52, 0x04, 0x62, 0x69, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x22, 0x29, 0x0a, 0x0f, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x22, 0x6f, 0x0a, 0x07, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x72, 0x0a, 0x10,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x28, 0x0a, 0x08, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x52, 0x08, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x22, 0x2b, 0x0a, 0x13, 0x54, 0x72, 0x61, 0x64, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x22, 0xdc, 0x02,
+	0x0a, 0x14, 0x54, 0x72, 0x61, 0x64, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x11, 0x65, 0x71, 0x75, 0x69, 0x76, 0x61,
+	0x6c, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x65, 0x71, 0x75, 0x69, 0x76, 0x61, 0x6c, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x64, 0x65, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x64,
+	0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x67,
+	0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e,
+	0x12, 0x24, 0x0a, 0x0d, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x50, 0x6e,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x6c, 0x69,
+	0x7a, 0x65, 0x64, 0x50, 0x6e, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x73, 0x74, 0x42, 0x61,
+	0x73, 0x69, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x73, 0x74, 0x42,
+	0x61, 0x73, 0x69, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x71, 0x75, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x65, 0x71, 0x75, 0x69, 0x74, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x72,
+	0x65, 0x65, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x66, 0x72, 0x65, 0x65, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61,
+	0x72, 0x67, 0x69, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1e, 0x0a, 0x0a,
+	0x75, 0x6e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x75, 0x6e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x22, 0x66, 0x0a, 0x0e,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a,
+	0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x61,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x61, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x32, 0xbe, 0x0a,
+	0x0a, 0x06, 0x54, 0x72, 0x61, 0x64, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x08, 0x41, 0x64, 0x64, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x41, 0x64, 0x64, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x62, 0x74, 0x68,
+	0x2e, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x12, 0x15, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x41, 0x64,
+	0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x3c, 0x0a, 0x09, 0x45, 0x64, 0x69, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x15,
+	0x2e, 0x62, 0x74, 0x68, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x45, 0x64, 0x69, 0x74,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x42, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x17,
+	0x2e, 0x62, 0x74, 0x68, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x73, 0x12, 0x18, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x62, 0x74, 0x68, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x41, 0x6c, 0x6c, 0x12, 0x15, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x62, 0x74, 0x68, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x6c, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0b, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x18, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0c,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x17, 0x2e, 0x62,
+	0x74, 0x68, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x12, 0x16, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x73, 0x12, 0x18, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
+	0x62, 0x74, 0x68, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x38, 0x0a, 0x0c, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x62, 0x74,
+	0x68, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x54, 0x72, 0x61, 0x64,
+	0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x39, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54,
+	0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x0b, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x22, 0x00, 0x30, 0x01,
+	0x12, 0x3a, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x70, 0x72, 0x65, 0x61, 0x64,
+	0x73, 0x12, 0x18, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4d, 0x61,
+	0x72, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x62, 0x74,
+	0x68, 0x2e, 0x53, 0x70, 0x72, 0x65, 0x61, 0x64, 0x22, 0x00, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x12,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x54, 0x72, 0x61, 0x64,
+	0x65, 0x73, 0x12, 0x18, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4d,
+	0x61, 0x72, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x62,
+	0x74, 0x68, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x54, 0x72, 0x61, 0x64, 0x65, 0x22, 0x00,
+	0x30, 0x01, 0x12, 0x3b, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x61, 0x6e, 0x64,
+	0x6c, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x43, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b,
+	0x2e, 0x62, 0x74, 0x68, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12,
+	0x37, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x12,
+	0x15, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0f, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x12, 0x15, 0x2e, 0x62, 0x74,
+	0x68, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f,
+	0x6f, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x14, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x42, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x62, 0x74,
+	0x68, 0x2e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x64, 0x65,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x54, 0x72,
+	0x61, 0x64, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41,
+	0x0a, 0x0e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x12, 0x14, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0x2b, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x0a, 0x2e, 0x62, 0x74,
+	0x68, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x62, 0x74, 0x68, 0x2e, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x08,
+	0x5a, 0x06, 0x2e, 0x2e, 0x2f, 0x62, 0x74, 0x68, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_proto_trader_proto_rawDescOnce sync.Once
+	file_api_proto_trader_proto_rawDescData = file_api_proto_trader_proto_rawDesc
+)
+
+func file_api_proto_trader_proto_rawDescGZIP() []byte {
+	file_api_proto_trader_proto_rawDescOnce.Do(func() {
+		file_api_proto_trader_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_proto_trader_proto_rawDescData)
+	})
+	return file_api_proto_trader_proto_rawDescData
+}
+
+var file_api_proto_trader_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_api_proto_trader_proto_goTypes = []interface{}{
+	(*AddOrderRequest)(nil),      // 0: bth.AddOrderRequest
+	(*AddOrderResponse)(nil),     // 1: bth.AddOrderResponse
+	(*AddOrdersRequest)(nil),     // 2: bth.AddOrdersRequest
+	(*AddOrderResult)(nil),       // 3: bth.AddOrderResult
+	(*AddOrdersResponse)(nil),    // 4: bth.AddOrdersResponse
+	(*EditOrderRequest)(nil),     // 5: bth.EditOrderRequest
+	(*EditOrderResponse)(nil),    // 6: bth.EditOrderResponse
+	(*CancelOrderRequest)(nil),   // 7: bth.CancelOrderRequest
+	(*CancelOrderResponse)(nil),  // 8: bth.CancelOrderResponse
+	(*CancelOrdersRequest)(nil),  // 9: bth.CancelOrdersRequest
+	(*CancelOrderResult)(nil),    // 10: bth.CancelOrderResult
+	(*CancelOrdersResponse)(nil), // 11: bth.CancelOrdersResponse
+	(*CancelAllRequest)(nil),     // 12: bth.CancelAllRequest
+	(*CancelAllResponse)(nil),    // 13: bth.CancelAllResponse
+	(*OrderStatusRequest)(nil),   // 14: bth.OrderStatusRequest
+	(*OrderStatusResponse)(nil),  // 15: bth.OrderStatusResponse
+	(*StreamOrdersRequest)(nil),  // 16: bth.StreamOrdersRequest
+	(*ListOrdersRequest)(nil),    // 17: bth.ListOrdersRequest
+	(*ListOrdersResponse)(nil),   // 18: bth.ListOrdersResponse
+	(*OrderTransition)(nil),      // 19: bth.OrderTransition
+	(*OrderHistoryResponse)(nil), // 20: bth.OrderHistoryResponse
+	(*StreamTradesRequest)(nil),  // 21: bth.StreamTradesRequest
+	(*Trade)(nil),                // 22: bth.Trade
+	(*StreamMarketRequest)(nil),  // 23: bth.StreamMarketRequest
+	(*StreamCandlesRequest)(nil), // 24: bth.StreamCandlesRequest
+	(*Ticker)(nil),               // 25: bth.Ticker
+	(*Spread)(nil),               // 26: bth.Spread
+	(*PublicTrade)(nil),          // 27: bth.PublicTrade
+	(*Candle)(nil),               // 28: bth.Candle
+	(*OrderBookRequest)(nil),     // 29: bth.OrderBookRequest
+	(*OrderBookLevel)(nil),       // 30: bth.OrderBookLevel
+	(*OrderBook)(nil),            // 31: bth.OrderBook
+	(*BalancesRequest)(nil),      // 32: bth.BalancesRequest
+	(*Balance)(nil),              // 33: bth.Balance
+	(*BalancesResponse)(nil),     // 34: bth.BalancesResponse
+	(*TradeBalanceRequest)(nil),  // 35: bth.TradeBalanceRequest
+	(*TradeBalanceResponse)(nil), // 36: bth.TradeBalanceResponse
+	(*HealthResponse)(nil),       // 37: bth.HealthResponse
+	(*Empty)(nil),                // 38: bth.Empty
+}
 var file_api_proto_trader_proto_depIdxs = []int32{
-	0, // 0: bth.Trader.AddOrder:input_type -> bth.AddOrderRequest
-	2, // 1: bth.Trader.CancelOrder:input_type -> bth.CancelOrderRequest
-	4, // 2: bth.Trader.OrderStatus:input_type -> bth.OrderStatusRequest
-	6, // 3: bth.Trader.StreamOrders:input_type -> bth.Empty
-	1, // 4: bth.Trader.AddOrder:output_type -> bth.AddOrderResponse
-	3, // 5: bth.Trader.CancelOrder:output_type -> bth.CancelOrderResponse
-	5, // 6: bth.Trader.OrderStatus:output_type -> bth.OrderStatusResponse
-	5, // 7: bth.Trader.StreamOrders:output_type -> bth.OrderStatusResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: bth.AddOrdersRequest.orders:type_name -> bth.AddOrderRequest
+	3,  // 1: bth.AddOrdersResponse.results:type_name -> bth.AddOrderResult
+	10, // 2: bth.CancelOrdersResponse.results:type_name -> bth.CancelOrderResult
+	15, // 3: bth.ListOrdersResponse.orders:type_name -> bth.OrderStatusResponse
+	19, // 4: bth.OrderHistoryResponse.transitions:type_name -> bth.OrderTransition
+	30, // 5: bth.OrderBook.asks:type_name -> bth.OrderBookLevel
+	30, // 6: bth.OrderBook.bids:type_name -> bth.OrderBookLevel
+	33, // 7: bth.BalancesResponse.balances:type_name -> bth.Balance
+	0,  // 8: bth.Trader.AddOrder:input_type -> bth.AddOrderRequest
+	2,  // 9: bth.Trader.AddOrders:input_type -> bth.AddOrdersRequest
+	5,  // 10: bth.Trader.EditOrder:input_type -> bth.EditOrderRequest
+	7,  // 11: bth.Trader.CancelOrder:input_type -> bth.CancelOrderRequest
+	9,  // 12: bth.Trader.CancelOrders:input_type -> bth.CancelOrdersRequest
+	12, // 13: bth.Trader.CancelAll:input_type -> bth.CancelAllRequest
+	14, // 14: bth.Trader.OrderStatus:input_type -> bth.OrderStatusRequest
+	14, // 15: bth.Trader.OrderHistory:input_type -> bth.OrderStatusRequest
+	17, // 16: bth.Trader.ListOrders:input_type -> bth.ListOrdersRequest
+	16, // 17: bth.Trader.StreamOrders:input_type -> bth.StreamOrdersRequest
+	21, // 18: bth.Trader.StreamTrades:input_type -> bth.StreamTradesRequest
+	23, // 19: bth.Trader.StreamTicker:input_type -> bth.StreamMarketRequest
+	23, // 20: bth.Trader.StreamSpreads:input_type -> bth.StreamMarketRequest
+	23, // 21: bth.Trader.StreamPublicTrades:input_type -> bth.StreamMarketRequest
+	24, // 22: bth.Trader.StreamCandles:input_type -> bth.StreamCandlesRequest
+	29, // 23: bth.Trader.GetOrderBook:input_type -> bth.OrderBookRequest
+	29, // 24: bth.Trader.StreamOrderBook:input_type -> bth.OrderBookRequest
+	32, // 25: bth.Trader.GetBalances:input_type -> bth.BalancesRequest
+	35, // 26: bth.Trader.GetTradeBalance:input_type -> bth.TradeBalanceRequest
+	32, // 27: bth.Trader.StreamBalances:input_type -> bth.BalancesRequest
+	38, // 28: bth.Trader.Health:input_type -> bth.Empty
+	1,  // 29: bth.Trader.AddOrder:output_type -> bth.AddOrderResponse
+	4,  // 30: bth.Trader.AddOrders:output_type -> bth.AddOrdersResponse
+	6,  // 31: bth.Trader.EditOrder:output_type -> bth.EditOrderResponse
+	8,  // 32: bth.Trader.CancelOrder:output_type -> bth.CancelOrderResponse
+	11, // 33: bth.Trader.CancelOrders:output_type -> bth.CancelOrdersResponse
+	13, // 34: bth.Trader.CancelAll:output_type -> bth.CancelAllResponse
+	15, // 35: bth.Trader.OrderStatus:output_type -> bth.OrderStatusResponse
+	20, // 36: bth.Trader.OrderHistory:output_type -> bth.OrderHistoryResponse
+	18, // 37: bth.Trader.ListOrders:output_type -> bth.ListOrdersResponse
+	15, // 38: bth.Trader.StreamOrders:output_type -> bth.OrderStatusResponse
+	22, // 39: bth.Trader.StreamTrades:output_type -> bth.Trade
+	25, // 40: bth.Trader.StreamTicker:output_type -> bth.Ticker
+	26, // 41: bth.Trader.StreamSpreads:output_type -> bth.Spread
+	27, // 42: bth.Trader.StreamPublicTrades:output_type -> bth.PublicTrade
+	28, // 43: bth.Trader.StreamCandles:output_type -> bth.Candle
+	31, // 44: bth.Trader.GetOrderBook:output_type -> bth.OrderBook
+	31, // 45: bth.Trader.StreamOrderBook:output_type -> bth.OrderBook
+	34, // 46: bth.Trader.GetBalances:output_type -> bth.BalancesResponse
+	36, // 47: bth.Trader.GetTradeBalance:output_type -> bth.TradeBalanceResponse
+	34, // 48: bth.Trader.StreamBalances:output_type -> bth.BalancesResponse
+	37, // 49: bth.Trader.Health:output_type -> bth.HealthResponse
+	29, // [29:50] is the sub-list for method output_type
+	8,  // [8:29] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_trader_proto_init() }
@@ -505,8 +3969,104 @@ func file_api_proto_trader_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_trader_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddOrderResponse); i {
+		file_api_proto_trader_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddOrdersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddOrderResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddOrdersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EditOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EditOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelOrdersRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -517,8 +4077,8 @@ func file_api_proto_trader_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_trader_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CancelOrderRequest); i {
+		file_api_proto_trader_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelOrderResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -529,8 +4089,8 @@ func file_api_proto_trader_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_trader_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CancelOrderResponse); i {
+		file_api_proto_trader_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelOrdersResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -541,7 +4101,31 @@ func file_api_proto_trader_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_trader_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_trader_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelAllRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelAllResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OrderStatusRequest); i {
 			case 0:
 				return &v.state
@@ -553,7 +4137,7 @@ func file_api_proto_trader_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_trader_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_trader_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OrderStatusResponse); i {
 			case 0:
 				return &v.state
@@ -565,7 +4149,271 @@ func file_api_proto_trader_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_trader_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_trader_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamOrdersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListOrdersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListOrdersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderTransition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamTradesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Trade); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamMarketRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamCandlesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ticker); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Spread); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublicTrade); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Candle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderBookRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderBookLevel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderBook); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BalancesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Balance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BalancesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TradeBalanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TradeBalanceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HealthResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_trader_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Empty); i {
 			case 0:
 				return &v.state
@@ -578,13 +4426,28 @@ func file_api_proto_trader_proto_init() {
 			}
 		}
 	}
+	file_api_proto_trader_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*EditOrderRequest_RefId)(nil),
+		(*EditOrderRequest_OrderId)(nil),
+		(*EditOrderRequest_ClientOrderId)(nil),
+	}
+	file_api_proto_trader_proto_msgTypes[7].OneofWrappers = []interface{}{
+		(*CancelOrderRequest_RefId)(nil),
+		(*CancelOrderRequest_OrderId)(nil),
+		(*CancelOrderRequest_ClientOrderId)(nil),
+	}
+	file_api_proto_trader_proto_msgTypes[14].OneofWrappers = []interface{}{
+		(*OrderStatusRequest_RefId)(nil),
+		(*OrderStatusRequest_OrderId)(nil),
+		(*OrderStatusRequest_ClientOrderId)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_proto_trader_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
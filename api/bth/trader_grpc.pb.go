@@ -24,12 +24,50 @@ const _ = grpc.SupportPackageIsVersion7
 type TraderClient interface {
 	// AddOrder submits a new order on the exchange
 	AddOrder(ctx context.Context, in *AddOrderRequest, opts ...grpc.CallOption) (*AddOrderResponse, error)
+	// AddOrders submits a list of orders, each order gets its own result
+	AddOrders(ctx context.Context, in *AddOrdersRequest, opts ...grpc.CallOption) (*AddOrdersResponse, error)
+	// EditOrder changes price or volume of an open order, the order keeps its refId
+	EditOrder(ctx context.Context, in *EditOrderRequest, opts ...grpc.CallOption) (*EditOrderResponse, error)
 	// CancelOrder cancels an open order
 	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
+	// CancelOrders cancels a list of orders with one request to the exchange, each order gets its own result
+	// unknown orders fail separately, the exchange cancels either all other orders or none of them
+	CancelOrders(ctx context.Context, in *CancelOrdersRequest, opts ...grpc.CallOption) (*CancelOrdersResponse, error)
+	// CancelAll cancels all open orders, or only orders of the pair if it is set
+	CancelAll(ctx context.Context, in *CancelAllRequest, opts ...grpc.CallOption) (*CancelAllResponse, error)
 	// OrderStatus request status of particular order
 	OrderStatus(ctx context.Context, in *OrderStatusRequest, opts ...grpc.CallOption) (*OrderStatusResponse, error)
+	// OrderHistory returns transitions of the order status in order of their appearance
+	OrderHistory(ctx context.Context, in *OrderStatusRequest, opts ...grpc.CallOption) (*OrderHistoryResponse, error)
+	// ListOrders returns orders known to the service page by page
+	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
 	// StreamOrders opens stream to receive update on order statuses as they become available
-	StreamOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Trader_StreamOrdersClient, error)
+	// The stream starts with a snapshot of matching orders, or resumes after fromSequence
+	StreamOrders(ctx context.Context, in *StreamOrdersRequest, opts ...grpc.CallOption) (Trader_StreamOrdersClient, error)
+	// StreamTrades opens stream to receive own trades as they happen, optionally filtered by pair and refId
+	// Trades are never dropped: a client too slow to read them gets RESOURCE_EXHAUSTED and should restart the stream
+	StreamTrades(ctx context.Context, in *StreamTradesRequest, opts ...grpc.CallOption) (Trader_StreamTradesClient, error)
+	// StreamTicker opens stream of ticker updates of the pairs
+	StreamTicker(ctx context.Context, in *StreamMarketRequest, opts ...grpc.CallOption) (Trader_StreamTickerClient, error)
+	// StreamSpreads opens stream of the best bid and ask of the pairs
+	StreamSpreads(ctx context.Context, in *StreamMarketRequest, opts ...grpc.CallOption) (Trader_StreamSpreadsClient, error)
+	// StreamPublicTrades opens stream of all trades of the pairs made on the exchange
+	StreamPublicTrades(ctx context.Context, in *StreamMarketRequest, opts ...grpc.CallOption) (Trader_StreamPublicTradesClient, error)
+	// StreamCandles opens stream of OHLC updates of the pairs
+	StreamCandles(ctx context.Context, in *StreamCandlesRequest, opts ...grpc.CallOption) (Trader_StreamCandlesClient, error)
+	// GetOrderBook returns the current order book of the pair, it waits for the snapshot if the book is not maintained yet
+	GetOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (*OrderBook, error)
+	// StreamOrderBook opens stream of the order book of the pair, the whole book is sent after every change
+	// Changes made while the previous book is being sent are coalesced
+	StreamOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (Trader_StreamOrderBookClient, error)
+	// GetBalances returns balances of the account with funds reserved by open orders placed through the service
+	GetBalances(ctx context.Context, in *BalancesRequest, opts ...grpc.CallOption) (*BalancesResponse, error)
+	// GetTradeBalance returns the summary of collateral balances and margin positions
+	GetTradeBalance(ctx context.Context, in *TradeBalanceRequest, opts ...grpc.CallOption) (*TradeBalanceResponse, error)
+	// StreamBalances opens stream of balances, balances are sent after every change of them or of reserved funds
+	StreamBalances(ctx context.Context, in *BalancesRequest, opts ...grpc.CallOption) (Trader_StreamBalancesClient, error)
+	// Health reports state of the connection to the exchange
+	Health(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HealthResponse, error)
 }
 
 type traderClient struct {
@@ -49,6 +87,24 @@ func (c *traderClient) AddOrder(ctx context.Context, in *AddOrderRequest, opts .
 	return out, nil
 }
 
+func (c *traderClient) AddOrders(ctx context.Context, in *AddOrdersRequest, opts ...grpc.CallOption) (*AddOrdersResponse, error) {
+	out := new(AddOrdersResponse)
+	err := c.cc.Invoke(ctx, "/bth.Trader/AddOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) EditOrder(ctx context.Context, in *EditOrderRequest, opts ...grpc.CallOption) (*EditOrderResponse, error) {
+	out := new(EditOrderResponse)
+	err := c.cc.Invoke(ctx, "/bth.Trader/EditOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *traderClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
 	out := new(CancelOrderResponse)
 	err := c.cc.Invoke(ctx, "/bth.Trader/CancelOrder", in, out, opts...)
@@ -58,6 +114,24 @@ func (c *traderClient) CancelOrder(ctx context.Context, in *CancelOrderRequest,
 	return out, nil
 }
 
+func (c *traderClient) CancelOrders(ctx context.Context, in *CancelOrdersRequest, opts ...grpc.CallOption) (*CancelOrdersResponse, error) {
+	out := new(CancelOrdersResponse)
+	err := c.cc.Invoke(ctx, "/bth.Trader/CancelOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) CancelAll(ctx context.Context, in *CancelAllRequest, opts ...grpc.CallOption) (*CancelAllResponse, error) {
+	out := new(CancelAllResponse)
+	err := c.cc.Invoke(ctx, "/bth.Trader/CancelAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *traderClient) OrderStatus(ctx context.Context, in *OrderStatusRequest, opts ...grpc.CallOption) (*OrderStatusResponse, error) {
 	out := new(OrderStatusResponse)
 	err := c.cc.Invoke(ctx, "/bth.Trader/OrderStatus", in, out, opts...)
@@ -67,7 +141,25 @@ func (c *traderClient) OrderStatus(ctx context.Context, in *OrderStatusRequest,
 	return out, nil
 }
 
-func (c *traderClient) StreamOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Trader_StreamOrdersClient, error) {
+func (c *traderClient) OrderHistory(ctx context.Context, in *OrderStatusRequest, opts ...grpc.CallOption) (*OrderHistoryResponse, error) {
+	out := new(OrderHistoryResponse)
+	err := c.cc.Invoke(ctx, "/bth.Trader/OrderHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
+	out := new(ListOrdersResponse)
+	err := c.cc.Invoke(ctx, "/bth.Trader/ListOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) StreamOrders(ctx context.Context, in *StreamOrdersRequest, opts ...grpc.CallOption) (Trader_StreamOrdersClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Trader_ServiceDesc.Streams[0], "/bth.Trader/StreamOrders", opts...)
 	if err != nil {
 		return nil, err
@@ -99,18 +191,316 @@ func (x *traderStreamOrdersClient) Recv() (*OrderStatusResponse, error) {
 	return m, nil
 }
 
+func (c *traderClient) StreamTrades(ctx context.Context, in *StreamTradesRequest, opts ...grpc.CallOption) (Trader_StreamTradesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Trader_ServiceDesc.Streams[1], "/bth.Trader/StreamTrades", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &traderStreamTradesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Trader_StreamTradesClient interface {
+	Recv() (*Trade, error)
+	grpc.ClientStream
+}
+
+type traderStreamTradesClient struct {
+	grpc.ClientStream
+}
+
+func (x *traderStreamTradesClient) Recv() (*Trade, error) {
+	m := new(Trade)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *traderClient) StreamTicker(ctx context.Context, in *StreamMarketRequest, opts ...grpc.CallOption) (Trader_StreamTickerClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Trader_ServiceDesc.Streams[2], "/bth.Trader/StreamTicker", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &traderStreamTickerClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Trader_StreamTickerClient interface {
+	Recv() (*Ticker, error)
+	grpc.ClientStream
+}
+
+type traderStreamTickerClient struct {
+	grpc.ClientStream
+}
+
+func (x *traderStreamTickerClient) Recv() (*Ticker, error) {
+	m := new(Ticker)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *traderClient) StreamSpreads(ctx context.Context, in *StreamMarketRequest, opts ...grpc.CallOption) (Trader_StreamSpreadsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Trader_ServiceDesc.Streams[3], "/bth.Trader/StreamSpreads", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &traderStreamSpreadsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Trader_StreamSpreadsClient interface {
+	Recv() (*Spread, error)
+	grpc.ClientStream
+}
+
+type traderStreamSpreadsClient struct {
+	grpc.ClientStream
+}
+
+func (x *traderStreamSpreadsClient) Recv() (*Spread, error) {
+	m := new(Spread)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *traderClient) StreamPublicTrades(ctx context.Context, in *StreamMarketRequest, opts ...grpc.CallOption) (Trader_StreamPublicTradesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Trader_ServiceDesc.Streams[4], "/bth.Trader/StreamPublicTrades", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &traderStreamPublicTradesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Trader_StreamPublicTradesClient interface {
+	Recv() (*PublicTrade, error)
+	grpc.ClientStream
+}
+
+type traderStreamPublicTradesClient struct {
+	grpc.ClientStream
+}
+
+func (x *traderStreamPublicTradesClient) Recv() (*PublicTrade, error) {
+	m := new(PublicTrade)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *traderClient) StreamCandles(ctx context.Context, in *StreamCandlesRequest, opts ...grpc.CallOption) (Trader_StreamCandlesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Trader_ServiceDesc.Streams[5], "/bth.Trader/StreamCandles", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &traderStreamCandlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Trader_StreamCandlesClient interface {
+	Recv() (*Candle, error)
+	grpc.ClientStream
+}
+
+type traderStreamCandlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *traderStreamCandlesClient) Recv() (*Candle, error) {
+	m := new(Candle)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *traderClient) GetOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (*OrderBook, error) {
+	out := new(OrderBook)
+	err := c.cc.Invoke(ctx, "/bth.Trader/GetOrderBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) StreamOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (Trader_StreamOrderBookClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Trader_ServiceDesc.Streams[6], "/bth.Trader/StreamOrderBook", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &traderStreamOrderBookClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Trader_StreamOrderBookClient interface {
+	Recv() (*OrderBook, error)
+	grpc.ClientStream
+}
+
+type traderStreamOrderBookClient struct {
+	grpc.ClientStream
+}
+
+func (x *traderStreamOrderBookClient) Recv() (*OrderBook, error) {
+	m := new(OrderBook)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *traderClient) GetBalances(ctx context.Context, in *BalancesRequest, opts ...grpc.CallOption) (*BalancesResponse, error) {
+	out := new(BalancesResponse)
+	err := c.cc.Invoke(ctx, "/bth.Trader/GetBalances", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) GetTradeBalance(ctx context.Context, in *TradeBalanceRequest, opts ...grpc.CallOption) (*TradeBalanceResponse, error) {
+	out := new(TradeBalanceResponse)
+	err := c.cc.Invoke(ctx, "/bth.Trader/GetTradeBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) StreamBalances(ctx context.Context, in *BalancesRequest, opts ...grpc.CallOption) (Trader_StreamBalancesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Trader_ServiceDesc.Streams[7], "/bth.Trader/StreamBalances", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &traderStreamBalancesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Trader_StreamBalancesClient interface {
+	Recv() (*BalancesResponse, error)
+	grpc.ClientStream
+}
+
+type traderStreamBalancesClient struct {
+	grpc.ClientStream
+}
+
+func (x *traderStreamBalancesClient) Recv() (*BalancesResponse, error) {
+	m := new(BalancesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *traderClient) Health(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, "/bth.Trader/Health", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TraderServer is the server API for Trader service.
 // All implementations must embed UnimplementedTraderServer
 // for forward compatibility
 type TraderServer interface {
 	// AddOrder submits a new order on the exchange
 	AddOrder(context.Context, *AddOrderRequest) (*AddOrderResponse, error)
+	// AddOrders submits a list of orders, each order gets its own result
+	AddOrders(context.Context, *AddOrdersRequest) (*AddOrdersResponse, error)
+	// EditOrder changes price or volume of an open order, the order keeps its refId
+	EditOrder(context.Context, *EditOrderRequest) (*EditOrderResponse, error)
 	// CancelOrder cancels an open order
 	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
+	// CancelOrders cancels a list of orders with one request to the exchange, each order gets its own result
+	// unknown orders fail separately, the exchange cancels either all other orders or none of them
+	CancelOrders(context.Context, *CancelOrdersRequest) (*CancelOrdersResponse, error)
+	// CancelAll cancels all open orders, or only orders of the pair if it is set
+	CancelAll(context.Context, *CancelAllRequest) (*CancelAllResponse, error)
 	// OrderStatus request status of particular order
 	OrderStatus(context.Context, *OrderStatusRequest) (*OrderStatusResponse, error)
+	// OrderHistory returns transitions of the order status in order of their appearance
+	OrderHistory(context.Context, *OrderStatusRequest) (*OrderHistoryResponse, error)
+	// ListOrders returns orders known to the service page by page
+	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
 	// StreamOrders opens stream to receive update on order statuses as they become available
-	StreamOrders(*Empty, Trader_StreamOrdersServer) error
+	// The stream starts with a snapshot of matching orders, or resumes after fromSequence
+	StreamOrders(*StreamOrdersRequest, Trader_StreamOrdersServer) error
+	// StreamTrades opens stream to receive own trades as they happen, optionally filtered by pair and refId
+	// Trades are never dropped: a client too slow to read them gets RESOURCE_EXHAUSTED and should restart the stream
+	StreamTrades(*StreamTradesRequest, Trader_StreamTradesServer) error
+	// StreamTicker opens stream of ticker updates of the pairs
+	StreamTicker(*StreamMarketRequest, Trader_StreamTickerServer) error
+	// StreamSpreads opens stream of the best bid and ask of the pairs
+	StreamSpreads(*StreamMarketRequest, Trader_StreamSpreadsServer) error
+	// StreamPublicTrades opens stream of all trades of the pairs made on the exchange
+	StreamPublicTrades(*StreamMarketRequest, Trader_StreamPublicTradesServer) error
+	// StreamCandles opens stream of OHLC updates of the pairs
+	StreamCandles(*StreamCandlesRequest, Trader_StreamCandlesServer) error
+	// GetOrderBook returns the current order book of the pair, it waits for the snapshot if the book is not maintained yet
+	GetOrderBook(context.Context, *OrderBookRequest) (*OrderBook, error)
+	// StreamOrderBook opens stream of the order book of the pair, the whole book is sent after every change
+	// Changes made while the previous book is being sent are coalesced
+	StreamOrderBook(*OrderBookRequest, Trader_StreamOrderBookServer) error
+	// GetBalances returns balances of the account with funds reserved by open orders placed through the service
+	GetBalances(context.Context, *BalancesRequest) (*BalancesResponse, error)
+	// GetTradeBalance returns the summary of collateral balances and margin positions
+	GetTradeBalance(context.Context, *TradeBalanceRequest) (*TradeBalanceResponse, error)
+	// StreamBalances opens stream of balances, balances are sent after every change of them or of reserved funds
+	StreamBalances(*BalancesRequest, Trader_StreamBalancesServer) error
+	// Health reports state of the connection to the exchange
+	Health(context.Context, *Empty) (*HealthResponse, error)
 	mustEmbedUnimplementedTraderServer()
 }
 
@@ -121,15 +511,66 @@ type UnimplementedTraderServer struct {
 func (UnimplementedTraderServer) AddOrder(context.Context, *AddOrderRequest) (*AddOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddOrder not implemented")
 }
+func (UnimplementedTraderServer) AddOrders(context.Context, *AddOrdersRequest) (*AddOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddOrders not implemented")
+}
+func (UnimplementedTraderServer) EditOrder(context.Context, *EditOrderRequest) (*EditOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditOrder not implemented")
+}
 func (UnimplementedTraderServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
 }
+func (UnimplementedTraderServer) CancelOrders(context.Context, *CancelOrdersRequest) (*CancelOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrders not implemented")
+}
+func (UnimplementedTraderServer) CancelAll(context.Context, *CancelAllRequest) (*CancelAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelAll not implemented")
+}
 func (UnimplementedTraderServer) OrderStatus(context.Context, *OrderStatusRequest) (*OrderStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method OrderStatus not implemented")
 }
-func (UnimplementedTraderServer) StreamOrders(*Empty, Trader_StreamOrdersServer) error {
+func (UnimplementedTraderServer) OrderHistory(context.Context, *OrderStatusRequest) (*OrderHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OrderHistory not implemented")
+}
+func (UnimplementedTraderServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrders not implemented")
+}
+func (UnimplementedTraderServer) StreamOrders(*StreamOrdersRequest, Trader_StreamOrdersServer) error {
 	return status.Errorf(codes.Unimplemented, "method StreamOrders not implemented")
 }
+func (UnimplementedTraderServer) StreamTrades(*StreamTradesRequest, Trader_StreamTradesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTrades not implemented")
+}
+func (UnimplementedTraderServer) StreamTicker(*StreamMarketRequest, Trader_StreamTickerServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTicker not implemented")
+}
+func (UnimplementedTraderServer) StreamSpreads(*StreamMarketRequest, Trader_StreamSpreadsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSpreads not implemented")
+}
+func (UnimplementedTraderServer) StreamPublicTrades(*StreamMarketRequest, Trader_StreamPublicTradesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPublicTrades not implemented")
+}
+func (UnimplementedTraderServer) StreamCandles(*StreamCandlesRequest, Trader_StreamCandlesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCandles not implemented")
+}
+func (UnimplementedTraderServer) GetOrderBook(context.Context, *OrderBookRequest) (*OrderBook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderBook not implemented")
+}
+func (UnimplementedTraderServer) StreamOrderBook(*OrderBookRequest, Trader_StreamOrderBookServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamOrderBook not implemented")
+}
+func (UnimplementedTraderServer) GetBalances(context.Context, *BalancesRequest) (*BalancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalances not implemented")
+}
+func (UnimplementedTraderServer) GetTradeBalance(context.Context, *TradeBalanceRequest) (*TradeBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTradeBalance not implemented")
+}
+func (UnimplementedTraderServer) StreamBalances(*BalancesRequest, Trader_StreamBalancesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBalances not implemented")
+}
+func (UnimplementedTraderServer) Health(context.Context, *Empty) (*HealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
 func (UnimplementedTraderServer) mustEmbedUnimplementedTraderServer() {}
 
 // UnsafeTraderServer may be embedded to opt out of forward compatibility for this service.
@@ -161,44 +602,152 @@ func _Trader_AddOrder_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Trader_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CancelOrderRequest)
+func _Trader_AddOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddOrdersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TraderServer).CancelOrder(ctx, in)
+		return srv.(TraderServer).AddOrders(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bth.Trader/CancelOrder",
+		FullMethod: "/bth.Trader/AddOrders",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TraderServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+		return srv.(TraderServer).AddOrders(ctx, req.(*AddOrdersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Trader_OrderStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderStatusRequest)
+func _Trader_EditOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditOrderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TraderServer).OrderStatus(ctx, in)
+		return srv.(TraderServer).EditOrder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bth.Trader/OrderStatus",
+		FullMethod: "/bth.Trader/EditOrder",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TraderServer).OrderStatus(ctx, req.(*OrderStatusRequest))
+		return srv.(TraderServer).EditOrder(ctx, req.(*EditOrderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Trader_StreamOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
+func _Trader_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bth.Trader/CancelOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_CancelOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).CancelOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bth.Trader/CancelOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).CancelOrders(ctx, req.(*CancelOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_CancelAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).CancelAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bth.Trader/CancelAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).CancelAll(ctx, req.(*CancelAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_OrderStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).OrderStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bth.Trader/OrderStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).OrderStatus(ctx, req.(*OrderStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_OrderHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).OrderHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bth.Trader/OrderHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).OrderHistory(ctx, req.(*OrderStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).ListOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bth.Trader/ListOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).ListOrders(ctx, req.(*ListOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_StreamOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamOrdersRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -218,6 +767,225 @@ func (x *traderStreamOrdersServer) Send(m *OrderStatusResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Trader_StreamTrades_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTradesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TraderServer).StreamTrades(m, &traderStreamTradesServer{stream})
+}
+
+type Trader_StreamTradesServer interface {
+	Send(*Trade) error
+	grpc.ServerStream
+}
+
+type traderStreamTradesServer struct {
+	grpc.ServerStream
+}
+
+func (x *traderStreamTradesServer) Send(m *Trade) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Trader_StreamTicker_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamMarketRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TraderServer).StreamTicker(m, &traderStreamTickerServer{stream})
+}
+
+type Trader_StreamTickerServer interface {
+	Send(*Ticker) error
+	grpc.ServerStream
+}
+
+type traderStreamTickerServer struct {
+	grpc.ServerStream
+}
+
+func (x *traderStreamTickerServer) Send(m *Ticker) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Trader_StreamSpreads_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamMarketRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TraderServer).StreamSpreads(m, &traderStreamSpreadsServer{stream})
+}
+
+type Trader_StreamSpreadsServer interface {
+	Send(*Spread) error
+	grpc.ServerStream
+}
+
+type traderStreamSpreadsServer struct {
+	grpc.ServerStream
+}
+
+func (x *traderStreamSpreadsServer) Send(m *Spread) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Trader_StreamPublicTrades_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamMarketRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TraderServer).StreamPublicTrades(m, &traderStreamPublicTradesServer{stream})
+}
+
+type Trader_StreamPublicTradesServer interface {
+	Send(*PublicTrade) error
+	grpc.ServerStream
+}
+
+type traderStreamPublicTradesServer struct {
+	grpc.ServerStream
+}
+
+func (x *traderStreamPublicTradesServer) Send(m *PublicTrade) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Trader_StreamCandles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamCandlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TraderServer).StreamCandles(m, &traderStreamCandlesServer{stream})
+}
+
+type Trader_StreamCandlesServer interface {
+	Send(*Candle) error
+	grpc.ServerStream
+}
+
+type traderStreamCandlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *traderStreamCandlesServer) Send(m *Candle) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Trader_GetOrderBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).GetOrderBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bth.Trader/GetOrderBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).GetOrderBook(ctx, req.(*OrderBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_StreamOrderBook_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OrderBookRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TraderServer).StreamOrderBook(m, &traderStreamOrderBookServer{stream})
+}
+
+type Trader_StreamOrderBookServer interface {
+	Send(*OrderBook) error
+	grpc.ServerStream
+}
+
+type traderStreamOrderBookServer struct {
+	grpc.ServerStream
+}
+
+func (x *traderStreamOrderBookServer) Send(m *OrderBook) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Trader_GetBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).GetBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bth.Trader/GetBalances",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).GetBalances(ctx, req.(*BalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_GetTradeBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TradeBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).GetTradeBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bth.Trader/GetTradeBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).GetTradeBalance(ctx, req.(*TradeBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_StreamBalances_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BalancesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TraderServer).StreamBalances(m, &traderStreamBalancesServer{stream})
+}
+
+type Trader_StreamBalancesServer interface {
+	Send(*BalancesResponse) error
+	grpc.ServerStream
+}
+
+type traderStreamBalancesServer struct {
+	grpc.ServerStream
+}
+
+func (x *traderStreamBalancesServer) Send(m *BalancesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Trader_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bth.Trader/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).Health(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Trader_ServiceDesc is the grpc.ServiceDesc for Trader service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,14 +997,54 @@ var Trader_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddOrder",
 			Handler:    _Trader_AddOrder_Handler,
 		},
+		{
+			MethodName: "AddOrders",
+			Handler:    _Trader_AddOrders_Handler,
+		},
+		{
+			MethodName: "EditOrder",
+			Handler:    _Trader_EditOrder_Handler,
+		},
 		{
 			MethodName: "CancelOrder",
 			Handler:    _Trader_CancelOrder_Handler,
 		},
+		{
+			MethodName: "CancelOrders",
+			Handler:    _Trader_CancelOrders_Handler,
+		},
+		{
+			MethodName: "CancelAll",
+			Handler:    _Trader_CancelAll_Handler,
+		},
 		{
 			MethodName: "OrderStatus",
 			Handler:    _Trader_OrderStatus_Handler,
 		},
+		{
+			MethodName: "OrderHistory",
+			Handler:    _Trader_OrderHistory_Handler,
+		},
+		{
+			MethodName: "ListOrders",
+			Handler:    _Trader_ListOrders_Handler,
+		},
+		{
+			MethodName: "GetOrderBook",
+			Handler:    _Trader_GetOrderBook_Handler,
+		},
+		{
+			MethodName: "GetBalances",
+			Handler:    _Trader_GetBalances_Handler,
+		},
+		{
+			MethodName: "GetTradeBalance",
+			Handler:    _Trader_GetTradeBalance_Handler,
+		},
+		{
+			MethodName: "Health",
+			Handler:    _Trader_Health_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -244,6 +1052,41 @@ var Trader_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Trader_StreamOrders_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamTrades",
+			Handler:       _Trader_StreamTrades_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamTicker",
+			Handler:       _Trader_StreamTicker_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamSpreads",
+			Handler:       _Trader_StreamSpreads_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamPublicTrades",
+			Handler:       _Trader_StreamPublicTrades_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamCandles",
+			Handler:       _Trader_StreamCandles_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamOrderBook",
+			Handler:       _Trader_StreamOrderBook_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamBalances",
+			Handler:       _Trader_StreamBalances_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/proto/trader.proto",
 }
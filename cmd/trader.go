@@ -2,11 +2,16 @@ package main
 
 import (
 	"bth-trader/api/bth"
+	"bth-trader/internal/assets"
+	"bth-trader/internal/balances"
+	"bth-trader/internal/book"
 	"bth-trader/internal/entities"
 	"bth-trader/internal/kraken"
 	"bth-trader/internal/kraken/decoder"
+	"bth-trader/internal/market"
 	"bth-trader/internal/orders"
 	"bth-trader/internal/server"
+	"bth-trader/internal/trades"
 	"bth-trader/internal/utils/env"
 	"fmt"
 	"github.com/ltunc/go-observer/observer"
@@ -15,55 +20,176 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
+// main reads parameters with env.Get, which adds env.Prefix to their names,
+// e.g. ASSETS_REFRESH is set by BTH_ASSETS_REFRESH, see the README for the full list
 func main() {
 	rest := kraken.NewRestClient(env.Get("KRAKEN_API_KEY", ""), env.Get("KRAKEN_PRIVATE_KEY", ""))
-	token, err := rest.WsToken()
-	if err != nil {
+	tokens := kraken.NewTokenManager(rest)
+	if err := tokens.Renew(); err != nil {
 		log.Printf("cannot receive auth token for Websocket requests: %v", err)
 		return
 	}
+	pairs := assets.NewRegistry(rest)
+	if err := pairs.Refresh(); err != nil {
+		log.Printf("cannot load asset pairs: %v", err)
+		return
+	}
+	go runAssetsRefresh(pairs)
 	ws := kraken.NewWsClient(kraken.WsEndpoint)
+	private := &privateSubs{ws: ws}
+	ws.States.Subscribe(connLogger{})
+	ws.States.Subscribe(&reconnectRefresher{tokens: tokens, subs: private})
 	if err := ws.Dial(); err != nil {
 		log.Fatalf("cannot dial kraken: %v", err)
 	}
-	if err := subKraken(ws, token); err != nil {
+	if err := subKraken(ws, tokens.Token()); err != nil {
 		log.Fatalf("cannot configure kraken WS: %v", err)
 	}
+	tokens.Renewed.Subscribe(private)
+	staleTimeout, err := time.ParseDuration(env.Get("KRAKEN_STALE_TIMEOUT", "10s"))
+	if err != nil {
+		log.Fatalf("cannot parse stale timeout: %v", err)
+	}
+	go ws.Watch(staleTimeout/2, staleTimeout)
+	go tokens.Run()
 	// read stream of messages from the server
 	out := &decoder.Outputs{
-		Orders: make(chan *entities.Order, 50),
-		Trades: make(chan *entities.Trade, 50),
+		Orders:  make(chan *entities.Order, 50),
+		Trades:  make(chan *entities.Trade, 50),
+		Errors:  make(chan string, 10),
+		Replies: ws.Requests(),
 	}
 	go decoder.DecodeStream(ws.Stream(), out)
-	go consume(out)
+	go watchErrors(out.Errors, tokens, private)
 	od := orders.NewDispatcher()
-	storage := orders.NewStorage()
+	storageDir := env.Get("STORAGE_DIR", "")
+	storage, closeStorage := openStorage(storageDir)
+	defer closeStorage()
+	refIds := openAllocator(storageDir, storage, rest)
 	go runStorageGc(storage)
 	od.Subscribe(storage)
+	// the feed publishes orders merged by the storage, so it is subscribed to updates accepted by the storage
+	feed := orders.NewFeed(streamBufferSize(), storage.Find)
+	storage.Updates().Subscribe(feed)
 	go orders.ReadFrom(od, out.Orders)
+	reconciler := orders.NewReconciler(rest, storage, od)
+	reconciler.PairName = pairs.WsName
+	ws.States.Subscribe(&reconnectReconciler{reconciler: reconciler})
+	go runReconciler(reconciler)
+	// reservations are calculated from orders merged by the storage, so the tracker is subscribed after the storage
+	tracker := balances.NewTracker(rest, pairs, storage)
+	od.Subscribe(tracker)
+	go runBalances(tracker)
+	td := trades.NewDispatcher()
+	go trades.ReadFrom(td, out.Trades)
+	public, m := openMarket(staleTimeout)
+	books := openBooks(public, m)
 	lis, err := net.Listen("tcp", env.Get("GRPC_LISTEN", "127.0.0.1:5500"))
 	if err != nil {
 		log.Fatalf("cannot open port: %v", err)
 	}
-	go runGrpc(lis, ws, token, od, storage)
+	activity := &server.Activity{}
+	go runGrpc(lis, ws, tokens, td, storage, refIds, feed, m, books, pairs, tracker, activity)
+	deadman := runDeadMansSwitch(ws, tokens, activity)
 	wait()
+	if deadman != nil {
+		if err := deadman.Disarm(); err != nil {
+			log.Printf("cannot disarm dead man's switch: %v", err)
+		}
+	}
+	_ = ws.Close()
+	_ = public.Close()
+}
+
+// openMarket connects to public channels of kraken and starts dispatching of market data
+// Public channels are subscribed only while gRPC clients listen to them.
+func openMarket(staleTimeout time.Duration) (*kraken.WsClient, *market.Market) {
+	public := kraken.NewWsClient(kraken.WsPublicEndpoint)
+	public.States.Subscribe(connLogger{})
+	if err := public.Dial(); err != nil {
+		log.Fatalf("cannot dial kraken public channels: %v", err)
+	}
+	go public.Watch(staleTimeout/2, staleTimeout)
+	out := &decoder.Outputs{
+		Tickers:      make(chan *entities.Ticker, 100),
+		PublicTrades: make(chan *entities.PublicTrade, 100),
+		Spreads:      make(chan *entities.Spread, 100),
+		Candles:      make(chan *entities.Candle, 100),
+		Books:        make(chan *entities.BookUpdate, 100),
+	}
+	go decoder.DecodeStream(public.Stream(), out)
+	m := market.NewMarket(public)
+	go market.ReadFrom(m.Tickers, out.Tickers)
+	go market.ReadFrom(m.Trades, out.PublicTrades)
+	go market.ReadFrom(m.Spreads, out.Spreads)
+	go market.ReadFrom(m.Candles, out.Candles)
+	go market.ReadFrom(m.Books, out.Books)
+	return public, m
+}
+
+// openBooks creates order books maintained for gRPC clients
+// Books of pairs listed in BOOK_PAIRS are maintained all the time with depth BOOK_DEPTH.
+// Books are dropped when the public connection is lost and wait for new snapshots.
+func openBooks(public *kraken.WsClient, m *market.Market) *book.Books {
+	books := book.NewBooks(m.Subs)
+	m.Books.Subscribe(books)
+	public.States.Subscribe(reconnectBooks{books: books})
+	depth, err := strconv.Atoi(env.Get("BOOK_DEPTH", strconv.Itoa(book.DefaultDepth)))
+	if err != nil {
+		log.Fatalf("cannot parse book depth: %v", err)
+	}
+	for _, pair := range strings.Split(env.Get("BOOK_PAIRS", ""), ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		if err := books.Acquire(pair, depth); err != nil {
+			log.Fatalf("cannot maintain order book of %s: %v", pair, err)
+		}
+	}
+	return books
+}
+
+// runDeadMansSwitch starts the dead man's switch if its timeout is configured
+// The switch is re-armed while kraken is connected and gRPC clients are active
+func runDeadMansSwitch(ws *kraken.WsClient, tokens *kraken.TokenManager, activity *server.Activity) *kraken.DeadMansSwitch {
+	timeout, err := time.ParseDuration(env.Get("DEADMAN_TIMEOUT", "0s"))
+	if err != nil {
+		log.Fatalf("cannot parse dead man's switch timeout: %v", err)
+	}
+	if timeout == 0 {
+		return nil
+	}
+	// Kraken counts the timeout in whole seconds
+	if timeout < time.Second {
+		log.Fatalf("dead man's switch timeout should be at least 1s, got %v", timeout)
+	}
+	healthy := func() bool {
+		return ws.State() == kraken.StateConnected && activity.Alive(timeout)
+	}
+	deadman := kraken.NewDeadMansSwitch(ws, tokens, timeout, healthy)
+	go deadman.Run()
+	return deadman
 }
 
 // subKraken subscribes kraken WS client for all necessary channels
-func subKraken(ws *kraken.WsClient, token *kraken.WsAuthToken) error {
+func subKraken(ws *kraken.WsClient, token string) error {
 	openOrders := kraken.SubMessage{
 		Event:        "subscribe",
-		Subscription: map[string]any{"name": "openOrders", "token": token.Token},
+		Subscription: map[string]any{"name": "openOrders", "token": token},
 	}
 	if err := ws.Subscribe(openOrders); err != nil {
 		return fmt.Errorf("cannot subscribe to open orders: %w", err)
 	}
 	ownTrades := kraken.SubMessage{
 		Event:        "subscribe",
-		Subscription: map[string]any{"name": "ownTrades", "token": token.Token},
+		Subscription: map[string]any{"name": "ownTrades", "token": token},
 	}
 	if err := ws.Subscribe(ownTrades); err != nil {
 		return fmt.Errorf("cannot subscribe to own trades: %w", err)
@@ -71,8 +197,206 @@ func subKraken(ws *kraken.WsClient, token *kraken.WsAuthToken) error {
 	return nil
 }
 
+// connLogger logs changes of the connection state to kraken
+type connLogger struct{}
+
+func (connLogger) Notify(s kraken.ConnState) {
+	if s == kraken.StateFailed {
+		log.Fatalf("kraken connection is lost and cannot be restored")
+	}
+	log.Printf("kraken connection state: %s", s)
+}
+
+// privateSubs re-subscribes to private channels with the renewed auth token if the subscriptions were lost
+// Subscriptions made with the previous token are kept by kraken, so they are not repeated on every renewal.
+type privateSubs struct {
+	ws *kraken.WsClient
+	// lost is set when the connection was restored or kraken rejected the token
+	lost atomic.Bool
+}
+
+// Lost marks subscriptions to be made again with the next token
+func (p *privateSubs) Lost() {
+	p.lost.Store(true)
+}
+
+func (p *privateSubs) Notify(token string) {
+	if !p.lost.Swap(false) {
+		return
+	}
+	if err := subKraken(p.ws, token); err != nil {
+		log.Printf("cannot re-subscribe with new token: %v", err)
+		p.Lost()
+	}
+}
+
+// reconnectRefresher requests new auth token after the connection to kraken was restored,
+// private channels are subscribed again with the new token
+type reconnectRefresher struct {
+	tokens *kraken.TokenManager
+	subs   *privateSubs
+	prev   kraken.ConnState
+}
+
+func (r *reconnectRefresher) Notify(s kraken.ConnState) {
+	if s == kraken.StateConnected && r.prev == kraken.StateReconnecting {
+		r.subs.Lost()
+		r.tokens.Refresh()
+	}
+	r.prev = s
+}
+
+// reconnectReconciler compares orders with the exchange after the connection to kraken was restored
+type reconnectReconciler struct {
+	reconciler *orders.Reconciler
+	prev       kraken.ConnState
+}
+
+func (r *reconnectReconciler) Notify(s kraken.ConnState) {
+	if s == kraken.StateConnected && r.prev == kraken.StateReconnecting {
+		go reconcile(r.reconciler)
+	}
+	r.prev = s
+}
+
+// reconnectBooks drops order books when the connection to kraken is lost, they are served again after new snapshots
+type reconnectBooks struct {
+	books *book.Books
+}
+
+func (r reconnectBooks) Notify(s kraken.ConnState) {
+	if s == kraken.StateReconnecting {
+		r.books.Reset()
+	}
+}
+
+// runAssetsRefresh reloads asset pairs periodically, the interval is set by ASSETS_REFRESH
+func runAssetsRefresh(pairs *assets.Registry) {
+	interval, err := time.ParseDuration(env.Get("ASSETS_REFRESH", "1h"))
+	if err != nil {
+		log.Fatalf("cannot parse assets refresh interval: %v", err)
+	}
+	if interval == 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	for range ticker.C {
+		if err := pairs.Refresh(); err != nil {
+			log.Printf("cannot refresh asset pairs: %v", err)
+		}
+	}
+}
+
+// runReconciler compares orders with the exchange on start and then periodically if RECONCILE_INTERVAL is set
+func runReconciler(r *orders.Reconciler) {
+	interval, err := time.ParseDuration(env.Get("RECONCILE_INTERVAL", "5m"))
+	if err != nil {
+		log.Fatalf("cannot parse reconcile interval: %v", err)
+	}
+	reconcile(r)
+	if interval == 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	for range ticker.C {
+		reconcile(r)
+	}
+}
+
+// runBalances reloads balances every BALANCES_REFRESH and after executions of orders
+func runBalances(tracker *balances.Tracker) {
+	interval, err := time.ParseDuration(env.Get("BALANCES_REFRESH", "30s"))
+	if err != nil {
+		log.Fatalf("cannot parse balances refresh interval: %v", err)
+	}
+	tracker.Run(interval)
+}
+
+func reconcile(r *orders.Reconciler) {
+	fixed, err := r.Reconcile()
+	if err != nil {
+		log.Printf("cannot reconcile orders: %v", err)
+	}
+	if fixed > 0 {
+		log.Printf("reconcile: %d orders corrected", fixed)
+	}
+}
+
+// watchErrors reads errors reported by kraken and requests new auth token if the current one is invalid
+func watchErrors(errs <-chan string, tokens *kraken.TokenManager, subs *privateSubs) {
+	for e := range errs {
+		if kraken.IsInvalidToken(e) {
+			// the error may be a reply to a subscription, so private channels are subscribed again
+			subs.Lost()
+			tokens.Refresh()
+		}
+	}
+}
+
+// openStorage opens the storage of orders, orders are kept on disk if the directory is set
+// returns function to close the storage
+func openStorage(dir string) (orders.Store, func()) {
+	if dir == "" {
+		return orders.NewStorage(), func() {}
+	}
+	storage, err := orders.OpenFileStorage(dir)
+	if err != nil {
+		log.Fatalf("cannot open storage of orders: %v", err)
+	}
+	return storage, func() {
+		if err := storage.Compact(); err != nil {
+			log.Printf("cannot compact storage of orders: %v", err)
+		}
+		if err := storage.Close(); err != nil {
+			log.Printf("cannot close storage of orders: %v", err)
+		}
+	}
+}
+
+// openAllocator creates the allocator of refIds for the instance set by INSTANCE_ID
+// The high-water mark of refIds is kept in the storage directory if it is set.
+// refIds of orders open on Kraken are skipped, they may be ahead of the mark if it is not kept.
+func openAllocator(dir string, storage orders.Store, exchange orders.Exchange) *orders.Allocator {
+	instance, err := strconv.Atoi(env.Get("INSTANCE_ID", "0"))
+	if err != nil {
+		log.Fatalf("cannot parse instance id: %v", err)
+	}
+	inUse := func(refId int) bool {
+		_, ok := storage.Find(refId)
+		return ok
+	}
+	path := ""
+	if dir != "" {
+		path = filepath.Join(dir, "refid")
+	}
+	refIds, err := orders.OpenAllocator(instance, path, inUse)
+	if err != nil {
+		log.Fatalf("cannot create allocator of refIds: %v", err)
+	}
+	open, err := exchange.OpenOrders()
+	if err != nil {
+		log.Printf("cannot get open orders to skip their refIds: %v", err)
+		return refIds
+	}
+	used := make([]int, 0, len(open))
+	for _, o := range open {
+		used = append(used, o.RefId)
+	}
+	refIds.Advance(used)
+	return refIds
+}
+
+// streamBufferSize returns number of order updates kept for resuming streams, set by STREAM_BUFFER
+func streamBufferSize() int {
+	size, err := strconv.Atoi(env.Get("STREAM_BUFFER", strconv.Itoa(orders.DefaultFeedSize)))
+	if err != nil {
+		log.Fatalf("cannot parse stream buffer size: %v", err)
+	}
+	return size
+}
+
 // runStorageGc executes cleaning process of the storage, removes old closed/finished orders
-func runStorageGc(s *orders.Storage) {
+func runStorageGc(s orders.Store) {
 	ticker := time.NewTicker(time.Second * 2)
 	for range ticker.C {
 		orders.Cleanup(s)
@@ -80,24 +404,20 @@ func runStorageGc(s *orders.Storage) {
 }
 
 // runGrpc prepares and starts gRPC server
-func runGrpc(lis net.Listener, ws *kraken.WsClient, t *kraken.WsAuthToken, dispatcher *observer.Subject[*entities.Order], storage *orders.Storage) {
-	var opts []grpc.ServerOption
+func runGrpc(lis net.Listener, ws *kraken.WsClient, t *kraken.TokenManager, td *observer.Subject[*entities.Trade], storage orders.Store, refIds *orders.Allocator, feed *orders.Feed, m *market.Market, books *book.Books, pairs *assets.Registry, tracker *balances.Tracker, activity *server.Activity) {
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(activity.Unary),
+		grpc.StreamInterceptor(activity.Stream),
+	}
 	srv := grpc.NewServer(opts...)
-	bth.RegisterTraderServer(srv, server.NewTraderServer(ws, t, dispatcher, storage))
-	log.Fatal(srv.Serve(lis))
-}
-
-// consume reads messages from output and prints it to logs
-// it is a temporary/debug reader
-func consume(out *decoder.Outputs) {
-	for {
-		select {
-		//case order := <-out.Orders:
-		//	log.Printf("order: %v", order)
-		case trade := <-out.Trades:
-			log.Printf("trade: %v", trade)
-		}
+	trader := server.NewTraderServer(ws, t, td, storage, refIds, feed, m, books, pairs, tracker)
+	window, err := time.ParseDuration(env.Get("IDEMPOTENCY_WINDOW", "24h"))
+	if err != nil {
+		log.Fatalf("cannot parse idempotency window: %v", err)
 	}
+	trader.IdempotencyWindow = window
+	bth.RegisterTraderServer(srv, trader)
+	log.Fatal(srv.Serve(lis))
 }
 
 // wait blocks goroutine until SIGINT received
@@ -4,13 +4,13 @@ go 1.19
 
 require (
 	github.com/gorilla/websocket v1.5.0
+	github.com/ltunc/go-observer v1.0.1
 	google.golang.org/grpc v1.48.0
 	google.golang.org/protobuf v1.28.1
 )
 
 require (
 	github.com/golang/protobuf v1.5.2 // indirect
-	github.com/ltunc/go-observer v1.0.1 // indirect
 	golang.org/x/net v0.0.0-20201021035429-f5854403a974 // indirect
 	golang.org/x/sys v0.0.0-20210119212857-b64e53b001e4 // indirect
 	golang.org/x/text v0.3.3 // indirect
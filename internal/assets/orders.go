@@ -0,0 +1,139 @@
+package assets
+
+import (
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/kraken"
+	"fmt"
+)
+
+// Normalize prepares the order for the pair: replaces the pair with its websocket name,
+// checks that prices fit the tick size and the volume fits decimals of the pair, checks limits and status of the pair
+// Values are never rounded, so the order is sent exactly as requested.
+// Returns kraken.ErrInvalidOrder with a description of the problem.
+func (r *Registry) Normalize(p *kraken.OrderParams) error {
+	pair, err := r.Pair(p.Pair)
+	if err != nil {
+		return fmt.Errorf("%w: %v", kraken.ErrInvalidOrder, err)
+	}
+	// the requested name is kept if the exchange did not send the websocket name of the pair
+	if pair.WsName != "" {
+		p.Pair = pair.WsName
+	}
+	if err := checkStatus(pair, p); err != nil {
+		return err
+	}
+	viqc := hasFlag(p.OFlags, kraken.FlagViqc)
+	if err := CheckVolume(pair, p.Volume, viqc); err != nil {
+		return err
+	}
+	if err := checkPrice(pair, "price", p.Price, p.PriceOffset, p.PricePercent); err != nil {
+		return err
+	}
+	if err := checkPrice(pair, "price2", p.Price2, p.Price2Offset, p.Price2Percent); err != nil {
+		return err
+	}
+	if viqc {
+		// the volume is in the quote currency, so it is the cost of the order
+		if p.Volume.Cmp(pair.CostMin) < 0 {
+			return fmt.Errorf("%w: cost %v is less than minimum %v of %s", kraken.ErrInvalidOrder, p.Volume, pair.CostMin, p.Pair)
+		}
+		return nil
+	}
+	if p.Volume.Cmp(pair.OrderMin) < 0 {
+		return fmt.Errorf("%w: volume %v is less than minimum %v of %s", kraken.ErrInvalidOrder, p.Volume, pair.OrderMin, p.Pair)
+	}
+	if price := limitPrice(p); price.Sign() > 0 {
+		if cost := price.Mul(p.Volume); cost.Cmp(pair.CostMin) < 0 {
+			return fmt.Errorf("%w: cost %v is less than minimum %v of %s", kraken.ErrInvalidOrder, cost, pair.CostMin, p.Pair)
+		}
+	}
+	return nil
+}
+
+// CheckPrice checks that the price is a multiple of the tick size of the pair, zero price is not checked
+// Returns kraken.ErrInvalidOrder if the price is off the tick.
+func CheckPrice(pair *kraken.AssetPair, price decimal.Decimal) error {
+	return checkPrice(pair, "price", price, "", false)
+}
+
+// CheckVolume checks that the volume has no more decimals than lot decimals of the pair
+// The volume in the quote currency (viqc flag) is checked against cost decimals instead.
+// Returns kraken.ErrInvalidOrder if the volume has more decimals.
+func CheckVolume(pair *kraken.AssetPair, volume decimal.Decimal, inQuote bool) error {
+	decimals := pair.LotDecimals
+	if inQuote {
+		decimals = pair.CostDecimals
+	}
+	if volume.Decimals() > int32(decimals) {
+		return fmt.Errorf("%w: volume %v has more than %d decimals allowed for %s", kraken.ErrInvalidOrder, volume, decimals, pairName(pair))
+	}
+	return nil
+}
+
+func checkPrice(pair *kraken.AssetPair, name string, price decimal.Decimal, offset string, percent bool) error {
+	if price.IsZero() || percent {
+		return nil
+	}
+	tick := pair.TickSize
+	if tick.Sign() <= 0 || offset != "" {
+		// offsets are not bound to the price grid, only to decimals of prices
+		tick = decimal.New(1, int32(pair.PairDecimals))
+	}
+	if !price.IsMultipleOf(tick) {
+		return fmt.Errorf("%w: %s %v is not a multiple of tick size %v of %s", kraken.ErrInvalidOrder, name, price, tick, pairName(pair))
+	}
+	return nil
+}
+
+// pairName returns the websocket name of the pair, or its REST name if the websocket name is not known
+func pairName(pair *kraken.AssetPair) string {
+	if pair.WsName != "" {
+		return pair.WsName
+	}
+	return pair.Name
+}
+
+// checkStatus checks that the pair accepts the order
+func checkStatus(pair *kraken.AssetPair, p *kraken.OrderParams) error {
+	switch pair.Status {
+	case kraken.PairOnline, "":
+		return nil
+	case kraken.PairPostOnly:
+		if p.OrderType == kraken.OrderLimit && hasFlag(p.OFlags, kraken.FlagPost) {
+			return nil
+		}
+	case kraken.PairLimitOnly:
+		if p.OrderType == kraken.OrderLimit {
+			return nil
+		}
+	case kraken.PairReduceOnly:
+		if p.ReduceOnly {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is %s", kraken.ErrInvalidOrder, pairName(pair), pair.Status)
+}
+
+// limitPrice returns the absolute limit price of the order, zero if the price is not known before execution
+func limitPrice(p *kraken.OrderParams) decimal.Decimal {
+	switch p.OrderType {
+	case kraken.OrderLimit:
+		if p.PriceOffset == "" {
+			return p.Price
+		}
+	case kraken.OrderStopLossLimit, kraken.OrderTakeProfitLimit:
+		if p.Price2Offset == "" {
+			return p.Price2
+		}
+	}
+	return decimal.Zero
+}
+
+func hasFlag(flags []string, flag string) bool {
+	for _, f := range flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package assets
+
+import (
+	"bth-trader/internal/kraken"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnknownPair is returned for a pair which is not known to the exchange
+var ErrUnknownPair = errors.New("unknown pair")
+
+// aliases are common names of assets which differ from names used by the exchange
+var aliases = map[string]string{"BTC": "XBT", "DOGE": "XDG"}
+
+// Source provides information about assets and pairs, e.g. *kraken.RestClient
+type Source interface {
+	AssetPairs() (map[string]*kraken.AssetPair, error)
+	Assets() (map[string]*kraken.Asset, error)
+}
+
+// Registry keeps information about pairs traded on the exchange
+// Pairs can be found by any of their names: XXBTZEUR, XBTEUR, XBT/EUR or BTC/EUR.
+type Registry struct {
+	source Source
+	// pairs contains pairs by all their names in upper case
+	pairs map[string]*kraken.AssetPair
+	// assets contains alternative names of assets by their names and alternative names
+	assets  map[string]string
+	updated time.Time
+	mu      *sync.Mutex
+}
+
+// NewRegistry creates an empty registry, it should be filled with Refresh
+func NewRegistry(source Source) *Registry {
+	return &Registry{
+		source: source,
+		mu:     &sync.Mutex{},
+	}
+}
+
+// Refresh loads assets and pairs from the source, the registry keeps previous data if loading fails
+func (r *Registry) Refresh() error {
+	assets, err := r.source.Assets()
+	if err != nil {
+		return err
+	}
+	pairs, err := r.source.AssetPairs()
+	if err != nil {
+		return err
+	}
+	assetNames := make(map[string]string, len(assets)*2)
+	for name, a := range assets {
+		assetNames[strings.ToUpper(name)] = a.AltName
+		assetNames[strings.ToUpper(a.AltName)] = a.AltName
+	}
+	pairNames := make(map[string]*kraken.AssetPair, len(pairs)*3)
+	for name, p := range pairs {
+		for _, n := range []string{name, p.AltName, p.WsName} {
+			if n != "" {
+				pairNames[strings.ToUpper(n)] = p
+			}
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.assets = assetNames
+	r.pairs = pairNames
+	r.updated = time.Now()
+	return nil
+}
+
+// Updated returns time of the last successful refresh
+func (r *Registry) Updated() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.updated
+}
+
+// Pair finds the pair by any of its names, names are case-insensitive
+func (r *Registry) Pair(name string) (*kraken.AssetPair, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if p, ok := r.pairs[name]; ok {
+		return p, nil
+	}
+	// sides of the pair can be named differently, e.g. BTC/EUR or XXBT/ZEUR instead of XBT/EUR
+	if base, quote, ok := strings.Cut(name, "/"); ok {
+		if p, ok := r.pairs[r.assetName(base)+"/"+r.assetName(quote)]; ok {
+			return p, nil
+		}
+	}
+	for alias, asset := range aliases {
+		if strings.HasPrefix(name, alias) {
+			if p, ok := r.pairs[asset+strings.TrimPrefix(name, alias)]; ok {
+				return p, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownPair, name)
+}
+
+// WsName returns the name of the pair used by websocket API, e.g. XBT/EUR for XBTEUR returned by REST API
+func (r *Registry) WsName(name string) (string, bool) {
+	p, err := r.Pair(name)
+	if err != nil || p.WsName == "" {
+		return "", false
+	}
+	return p.WsName, true
+}
+
+// AssetName returns the alternative name of the asset, e.g. XBT for XXBT or BTC, unknown names are returned in upper case
+func (r *Registry) AssetName(name string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.assetName(strings.ToUpper(strings.TrimSpace(name)))
+}
+
+func (r *Registry) assetName(name string) string {
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+	if alt, ok := r.assets[name]; ok {
+		return strings.ToUpper(alt)
+	}
+	return name
+}
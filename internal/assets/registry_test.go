@@ -0,0 +1,185 @@
+package assets
+
+import (
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/kraken"
+	"errors"
+	"testing"
+)
+
+type mockSource struct {
+	pairs  map[string]*kraken.AssetPair
+	assets map[string]*kraken.Asset
+	err    error
+}
+
+func (m *mockSource) AssetPairs() (map[string]*kraken.AssetPair, error) {
+	return m.pairs, m.err
+}
+
+func (m *mockSource) Assets() (map[string]*kraken.Asset, error) {
+	return m.assets, m.err
+}
+
+func newMockSource() *mockSource {
+	return &mockSource{
+		pairs: map[string]*kraken.AssetPair{
+			"XXBTZEUR": {
+				Name: "XXBTZEUR", AltName: "XBTEUR", WsName: "XBT/EUR", Base: "XXBT", Quote: "ZEUR",
+				PairDecimals: 1, CostDecimals: 5, LotDecimals: 8, TickSize: decimal.MustParse("0.1"), OrderMin: decimal.MustParse("0.0001"), CostMin: decimal.MustParse("0.45"), Status: kraken.PairOnline,
+			},
+			// the websocket name is not sent for some pairs
+			"XXBTZUSD": {
+				Name: "XXBTZUSD", AltName: "XBTUSD", Base: "XXBT", Quote: "ZUSD",
+				PairDecimals: 1, CostDecimals: 5, LotDecimals: 8, TickSize: decimal.MustParse("0.1"), OrderMin: decimal.MustParse("0.0001"), CostMin: decimal.MustParse("0.5"), Status: kraken.PairOnline,
+			},
+			"XETHZEUR": {
+				Name: "XETHZEUR", AltName: "ETHEUR", WsName: "ETH/EUR", Base: "XETH", Quote: "ZEUR",
+				PairDecimals: 2, LotDecimals: 8, TickSize: decimal.MustParse("0.05"), OrderMin: decimal.MustParse("0.01"), CostMin: decimal.MustParse("0.45"), Status: kraken.PairLimitOnly,
+			},
+		},
+		assets: map[string]*kraken.Asset{
+			"XXBT": {Name: "XXBT", AltName: "XBT"},
+			"XETH": {Name: "XETH", AltName: "ETH"},
+			"ZEUR": {Name: "ZEUR", AltName: "EUR"},
+			"ZUSD": {Name: "ZUSD", AltName: "USD"},
+		},
+	}
+}
+
+func TestRegistry_Pair(t *testing.T) {
+	source := newMockSource()
+	r := NewRegistry(source)
+	if _, err := r.Pair("XBT/EUR"); !errors.Is(err, ErrUnknownPair) {
+		t.Fatalf("Pair() before refresh error = %v, want ErrUnknownPair", err)
+	}
+	if err := r.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	for _, name := range []string{"XXBTZEUR", "XBTEUR", "XBT/EUR", "xbt/eur", "BTC/EUR", "BTCEUR", "XXBT/ZEUR"} {
+		p, err := r.Pair(name)
+		if err != nil || p.Name != "XXBTZEUR" {
+			t.Errorf("Pair(%q) = %v, %v, want XXBTZEUR", name, p, err)
+		}
+	}
+	if _, err := r.Pair("XBT/USD"); !errors.Is(err, ErrUnknownPair) {
+		t.Errorf("Pair() error = %v, want ErrUnknownPair", err)
+	}
+	if name, ok := r.WsName("ETHEUR"); !ok || name != "ETH/EUR" {
+		t.Errorf("WsName() = %v, %v, want ETH/EUR", name, ok)
+	}
+	for name, want := range map[string]string{"XXBT": "XBT", "btc": "XBT", "ZEUR": "EUR", "XBT.F": "XBT.F"} {
+		if got := r.AssetName(name); got != want {
+			t.Errorf("AssetName(%q) = %v, want %v", name, got, want)
+		}
+	}
+	source.err = errors.New("timeout")
+	if err := r.Refresh(); err == nil {
+		t.Errorf("Refresh() expected error")
+	}
+	if _, err := r.Pair("XBT/EUR"); err != nil {
+		t.Errorf("Pair() after failed refresh error = %v, previous data should be kept", err)
+	}
+}
+
+func TestRegistry_Normalize(t *testing.T) {
+	r := NewRegistry(newMockSource())
+	if err := r.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		params  kraken.OrderParams
+		want    kraken.OrderParams
+		wantErr bool
+	}{
+		{
+			name:   "keeps exact values",
+			params: kraken.OrderParams{Pair: "XXBTZEUR", Direction: "buy", OrderType: kraken.OrderLimit, Price: decimal.MustParse("20000.10"), Volume: decimal.MustParse("0.00123000")},
+			want:   kraken.OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: kraken.OrderLimit, Price: decimal.MustParse("20000.1"), Volume: decimal.MustParse("0.00123")},
+		},
+		{
+			name:    "price off tick",
+			params:  kraken.OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: kraken.OrderLimit, Price: decimal.MustParse("20000.15"), Volume: decimal.MustParse("0.001")},
+			wantErr: true,
+		},
+		{
+			name:    "volume with too many decimals",
+			params:  kraken.OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: kraken.OrderLimit, Price: decimal.MustParse("20000"), Volume: decimal.MustParse("0.000123456789")},
+			wantErr: true,
+		},
+		{
+			name:   "viqc volume with cost decimals",
+			params: kraken.OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: kraken.OrderMarket, OFlags: []string{kraken.FlagViqc}, Volume: decimal.MustParse("10.12345")},
+			want:   kraken.OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: kraken.OrderMarket, Volume: decimal.MustParse("10.12345")},
+		},
+		{
+			name:    "viqc volume with too many decimals",
+			params:  kraken.OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: kraken.OrderMarket, OFlags: []string{kraken.FlagViqc}, Volume: decimal.MustParse("10.123456")},
+			wantErr: true,
+		},
+		{
+			name:    "viqc below costmin",
+			params:  kraken.OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: kraken.OrderMarket, OFlags: []string{kraken.FlagViqc}, Volume: decimal.MustParse("0.4")},
+			wantErr: true,
+		},
+		{
+			name:    "off tick size of five cents",
+			params:  kraken.OrderParams{Pair: "ETH/EUR", Direction: "sell", OrderType: kraken.OrderLimit, Price: decimal.MustParse("1500.37"), Volume: decimal.MustParse("0.1")},
+			wantErr: true,
+		},
+		{
+			name:   "without websocket name",
+			params: kraken.OrderParams{Pair: "XBTUSD", Direction: "buy", OrderType: kraken.OrderLimit, Price: decimal.MustParse("20000"), Volume: decimal.MustParse("0.001")},
+			want:   kraken.OrderParams{Pair: "XBTUSD", Direction: "buy", OrderType: kraken.OrderLimit, Price: decimal.MustParse("20000"), Volume: decimal.MustParse("0.001")},
+		},
+		{
+			name:    "below ordermin",
+			params:  kraken.OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: kraken.OrderLimit, Price: decimal.MustParse("20000"), Volume: decimal.MustParse("0.00005")},
+			wantErr: true,
+		},
+		{
+			name:    "below costmin",
+			params:  kraken.OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: kraken.OrderLimit, Price: decimal.MustParse("1000"), Volume: decimal.MustParse("0.0002")},
+			wantErr: true,
+		},
+		{
+			name:   "tick size of five cents",
+			params: kraken.OrderParams{Pair: "ETH/EUR", Direction: "sell", OrderType: kraken.OrderLimit, Price: decimal.MustParse("1500.35"), Volume: decimal.MustParse("0.1")},
+			want:   kraken.OrderParams{Pair: "ETH/EUR", Direction: "sell", OrderType: kraken.OrderLimit, Price: decimal.MustParse("1500.35"), Volume: decimal.MustParse("0.1")},
+		},
+		{
+			name:    "limit only pair",
+			params:  kraken.OrderParams{Pair: "ETH/EUR", Direction: "sell", OrderType: kraken.OrderMarket, Volume: decimal.MustParse("0.1")},
+			wantErr: true,
+		},
+		{
+			name:   "offset is checked against decimals",
+			params: kraken.OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: kraken.OrderStopLoss, Price: decimal.MustParse("150.3"), PriceOffset: "-", Volume: decimal.MustParse("0.01")},
+			want:   kraken.OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: kraken.OrderStopLoss, Price: decimal.MustParse("150.3"), PriceOffset: "-", Volume: decimal.MustParse("0.01")},
+		},
+		{
+			name:    "unknown pair",
+			params:  kraken.OrderParams{Pair: "DOT/EUR", Direction: "buy", OrderType: kraken.OrderMarket, Volume: decimal.MustParse("1")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.params
+			err := r.Normalize(&p)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, kraken.ErrInvalidOrder) {
+					t.Errorf("Normalize() error = %v, want ErrInvalidOrder", err)
+				}
+				return
+			}
+			if p.Pair != tt.want.Pair || p.Price != tt.want.Price || p.Volume != tt.want.Volume {
+				t.Errorf("Normalize() = %+v, want %+v", p, tt.want)
+			}
+		})
+	}
+}
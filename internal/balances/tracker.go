@@ -0,0 +1,251 @@
+package balances
+
+import (
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/entities"
+	"bth-trader/internal/kraken"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotLoaded is returned until balances are loaded from the exchange for the first time
+var ErrNotLoaded = errors.New("balances are not loaded")
+
+// Source provides balances of the account, e.g. *kraken.RestClient
+type Source interface {
+	Balances() (kraken.Balances, error)
+	TradeBalance(asset string) (*kraken.TradeBalance, error)
+}
+
+// Pairs finds pairs of orders, e.g. *assets.Registry
+type Pairs interface {
+	Pair(name string) (*kraken.AssetPair, error)
+}
+
+// Orders selects orders known to the service, e.g. orders.Store
+type Orders interface {
+	Select(match func(o *entities.Order) bool) []*entities.Order
+}
+
+// Balance is the balance of an asset
+type Balance struct {
+	// Asset is the name of the asset used by REST API, e.g. XXBT or ZEUR
+	Asset string
+	// Total is the balance on the exchange
+	Total decimal.Decimal
+	// Reserved is the part of the balance held by open orders of the service
+	Reserved decimal.Decimal
+	// Available is the total balance without reserved funds, it is never negative
+	Available decimal.Decimal
+}
+
+// Snapshot is the state of balances
+type Snapshot struct {
+	// Balances are sorted by names of assets
+	Balances []Balance
+	// Updated is the time when balances were loaded from the exchange
+	Updated time.Time
+	// Seq is increased on every change of balances or reservations
+	Seq uint64
+}
+
+// Tracker keeps balances of the account and funds reserved by own open orders
+// Balances are polled from the exchange, reservations are calculated from open orders in the storage.
+// It is an observer of orders and should be subscribed after the storage: changes of orders change reservations,
+// executions make the tracker reload balances.
+type Tracker struct {
+	source  Source
+	pairs   Pairs
+	orders  Orders
+	totals  kraken.Balances
+	updated time.Time
+	seq     uint64
+	changed chan struct{}
+	// refresh requests reloading of balances, pending requests are coalesced
+	refresh chan struct{}
+	mu      *sync.Mutex
+}
+
+// NewTracker creates a tracker without balances, they are loaded by Refresh or Run
+func NewTracker(source Source, pairs Pairs, orders Orders) *Tracker {
+	return &Tracker{
+		source:  source,
+		pairs:   pairs,
+		orders:  orders,
+		changed: make(chan struct{}),
+		refresh: make(chan struct{}, 1),
+		mu:      &sync.Mutex{},
+	}
+}
+
+// Run reloads balances on start, then every interval and after executions of orders
+// Balances are reloaded only after executions if the interval is zero. Blocks the goroutine forever.
+func (t *Tracker) Run(interval time.Duration) {
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		if err := t.Refresh(); err != nil {
+			log.Printf("cannot refresh balances: %v", err)
+		}
+		select {
+		case <-tick:
+		case <-t.refresh:
+		}
+	}
+}
+
+// Refresh loads balances from the exchange, the tracker keeps previous balances if loading fails
+func (t *Tracker) Refresh() error {
+	totals, err := t.source.Balances()
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.updated = time.Now()
+	if t.totals != nil && equal(t.totals, totals) {
+		return nil
+	}
+	t.totals = totals
+	t.notifyLocked()
+	return nil
+}
+
+// TradeBalance returns the summary of margin trading in the asset, it is always loaded from the exchange
+func (t *Tracker) TradeBalance(asset string) (*kraken.TradeBalance, error) {
+	return t.source.TradeBalance(asset)
+}
+
+// Notify updates reservations after a change of the order and requests reloading of balances after its execution
+func (t *Tracker) Notify(o *entities.Order) {
+	t.mu.Lock()
+	t.notifyLocked()
+	t.mu.Unlock()
+	if !o.VolumeExec.IsZero() {
+		select {
+		case t.refresh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (t *Tracker) notifyLocked() {
+	t.seq++
+	close(t.changed)
+	t.changed = make(chan struct{})
+}
+
+// Snapshot returns current balances, ErrNotLoaded if they are not loaded yet
+func (t *Tracker) Snapshot() (*Snapshot, error) {
+	s, _, err := t.Watch()
+	return s, err
+}
+
+// Watch returns current balances and a channel which is closed when balances or reservations change
+// The snapshot is nil with ErrNotLoaded until balances are loaded.
+func (t *Tracker) Watch() (*Snapshot, <-chan struct{}, error) {
+	reserved := t.reserved()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.totals == nil {
+		return nil, t.changed, ErrNotLoaded
+	}
+	s := &Snapshot{Updated: t.updated, Seq: t.seq}
+	for asset, total := range t.totals {
+		s.Balances = append(s.Balances, balance(asset, total, reserved[asset]))
+	}
+	for asset, r := range reserved {
+		if _, ok := t.totals[asset]; !ok {
+			s.Balances = append(s.Balances, balance(asset, decimal.Zero, r))
+		}
+	}
+	sort.Slice(s.Balances, func(i, j int) bool {
+		return s.Balances[i].Asset < s.Balances[j].Asset
+	})
+	return s, t.changed, nil
+}
+
+func balance(asset string, total, reserved decimal.Decimal) Balance {
+	available := total.Sub(reserved)
+	if available.Sign() < 0 {
+		available = decimal.Zero
+	}
+	return Balance{Asset: asset, Total: total, Reserved: reserved, Available: available}
+}
+
+// reserved calculates funds held by open orders by names of assets
+// Sell orders hold the rest of their volume in the base asset, buy orders hold its cost at the limit price
+// in the quote asset. Market orders are executed at once and fees are not known, so they are not counted.
+func (t *Tracker) reserved() map[string]decimal.Decimal {
+	open := t.orders.Select(func(o *entities.Order) bool {
+		return !o.IsFinished()
+	})
+	result := make(map[string]decimal.Decimal)
+	for _, o := range open {
+		rest := o.Volume.Sub(o.VolumeExec)
+		if rest.Sign() <= 0 {
+			continue
+		}
+		pair, err := t.pairs.Pair(o.Pair)
+		if err != nil {
+			continue
+		}
+		switch {
+		case o.Side == "sell":
+			result[pair.Base] = result[pair.Base].Add(rest)
+		case o.Side == "buy" && hasFlag(o.OFlags, kraken.FlagViqc):
+			// the volume is in the quote asset
+			result[pair.Quote] = result[pair.Quote].Add(rest)
+		case o.Side == "buy":
+			if price := limitPrice(o); price.Sign() > 0 {
+				result[pair.Quote] = result[pair.Quote].Add(rest.Mul(price))
+			}
+		}
+	}
+	return result
+}
+
+// limitPrice returns the price at which the order can be executed, zero if it is not known
+func limitPrice(o *entities.Order) decimal.Decimal {
+	if !o.LimitPrice.IsZero() {
+		// set by the exchange for triggered orders
+		return o.LimitPrice
+	}
+	switch o.OrderType {
+	case kraken.OrderLimit:
+		return o.Price
+	case kraken.OrderStopLossLimit, kraken.OrderTakeProfitLimit:
+		return o.Price2
+	}
+	return decimal.Zero
+}
+
+// hasFlag checks comma separated order flags sent by the exchange
+func hasFlag(flags, flag string) bool {
+	for _, f := range strings.Split(flags, ",") {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func equal(a, b kraken.Balances) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for asset, v := range a {
+		if w, ok := b[asset]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package balances
+
+import (
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/entities"
+	"bth-trader/internal/kraken"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type mockSource struct {
+	balances kraken.Balances
+	err      error
+	calls    int
+}
+
+func (m *mockSource) Balances() (kraken.Balances, error) {
+	m.calls++
+	return m.balances, m.err
+}
+
+func (m *mockSource) TradeBalance(string) (*kraken.TradeBalance, error) {
+	return &kraken.TradeBalance{}, m.err
+}
+
+type mockPairs map[string]*kraken.AssetPair
+
+func (m mockPairs) Pair(name string) (*kraken.AssetPair, error) {
+	if p, ok := m[name]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("unknown pair %q", name)
+}
+
+type mockOrders []*entities.Order
+
+func (m mockOrders) Select(match func(o *entities.Order) bool) []*entities.Order {
+	var result []*entities.Order
+	for _, o := range m {
+		if match(o) {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+var pairs = mockPairs{
+	"XBT/EUR": {WsName: "XBT/EUR", Base: "XXBT", Quote: "ZEUR"},
+	"ETH/EUR": {WsName: "ETH/EUR", Base: "XETH", Quote: "ZEUR"},
+}
+
+func d(s string) decimal.Decimal {
+	return decimal.MustParse(s)
+}
+
+func TestTracker_Watch(t *testing.T) {
+	source := &mockSource{balances: kraken.Balances{"ZEUR": d("1000"), "XXBT": d("0.5")}}
+	open := mockOrders{
+		// buy 0.02 of 0.03 left at 20000 = 400 EUR
+		{RefId: 1, Pair: "XBT/EUR", Side: "buy", OrderType: kraken.OrderLimit, Status: "open", Volume: d("0.03"), VolumeExec: d("0.01"), Price: d("20000")},
+		{RefId: 2, Pair: "XBT/EUR", Side: "sell", OrderType: kraken.OrderLimit, Status: "open", Volume: d("0.1"), Price: d("30000")},
+		{RefId: 3, Pair: "ETH/EUR", Side: "buy", OrderType: kraken.OrderStopLossLimit, Status: "pending", Volume: d("0.1"), Price: d("1600"), Price2: d("1650.5")},
+		{RefId: 4, Pair: "ETH/EUR", Side: "buy", OrderType: kraken.OrderLimit, Status: "open", Volume: d("50"), OFlags: "post,viqc", Price: d("1500")},
+		{RefId: 5, Pair: "XBT/EUR", Side: "buy", OrderType: kraken.OrderLimit, Status: "closed", Volume: d("1"), Price: d("20000")},
+		{RefId: 6, Pair: "XBT/EUR", Side: "buy", OrderType: kraken.OrderMarket, Status: "open", Volume: d("1")},
+		{RefId: 7, Pair: "ETH/EUR", Side: "sell", OrderType: kraken.OrderLimit, Status: "open", Volume: d("2"), Price: d("1700")},
+	}
+	tr := NewTracker(source, pairs, open)
+	if _, err := tr.Snapshot(); !errors.Is(err, ErrNotLoaded) {
+		t.Fatalf("Snapshot() before refresh error = %v, want ErrNotLoaded", err)
+	}
+	if err := tr.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	s, err := tr.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	want := []Balance{
+		// 2 ETH are reserved, but there are no ETH on the account
+		{Asset: "XETH", Total: d("0"), Reserved: d("2"), Available: d("0")},
+		{Asset: "XXBT", Total: d("0.5"), Reserved: d("0.1"), Available: d("0.4")},
+		// 400 + 165.05 + 50
+		{Asset: "ZEUR", Total: d("1000"), Reserved: d("615.05"), Available: d("384.95")},
+	}
+	if !reflect.DeepEqual(s.Balances, want) {
+		t.Errorf("Snapshot() = %+v, want %+v", s.Balances, want)
+	}
+}
+
+func TestTracker_Refresh(t *testing.T) {
+	source := &mockSource{balances: kraken.Balances{"ZEUR": d("1000")}}
+	tr := NewTracker(source, pairs, mockOrders{})
+	_, changed, _ := tr.Watch()
+	if err := tr.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	select {
+	case <-changed:
+	default:
+		t.Fatalf("the first refresh should notify watchers")
+	}
+	s, changed, _ := tr.Watch()
+	source.balances = kraken.Balances{"ZEUR": d("1000.00")}
+	_ = tr.Refresh()
+	select {
+	case <-changed:
+		t.Errorf("refresh without changes should not notify watchers")
+	default:
+	}
+	source.err = errors.New("timeout")
+	if err := tr.Refresh(); err == nil {
+		t.Errorf("Refresh() expected error")
+	}
+	if got, err := tr.Snapshot(); err != nil || got.Seq != s.Seq || got.Balances[0].Total != d("1000") {
+		t.Errorf("Snapshot() after failed refresh = %+v, %v, previous balances should be kept", got, err)
+	}
+}
+
+func TestTracker_Notify(t *testing.T) {
+	tr := NewTracker(&mockSource{balances: kraken.Balances{}}, pairs, mockOrders{})
+	_, changed, _ := tr.Watch()
+	tr.Notify(&entities.Order{RefId: 1, Status: "open"})
+	select {
+	case <-changed:
+	default:
+		t.Errorf("change of an order should notify watchers")
+	}
+	select {
+	case <-tr.refresh:
+		t.Errorf("order without execution should not request refresh")
+	default:
+	}
+	tr.Notify(&entities.Order{RefId: 1, VolumeExec: d("0.1")})
+	tr.Notify(&entities.Order{RefId: 1, VolumeExec: d("0.2")})
+	if len(tr.refresh) != 1 {
+		t.Errorf("execution should request refresh once, got %d requests", len(tr.refresh))
+	}
+}
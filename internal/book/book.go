@@ -0,0 +1,171 @@
+package book
+
+import (
+	"bth-trader/internal/entities"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strings"
+	"time"
+)
+
+// checksumLevels is number of top levels of each side included in checksums
+const checksumLevels = 10
+
+var (
+	// ErrNotSynced is returned while the book waits for a snapshot, e.g. after a checksum mismatch
+	ErrNotSynced = errors.New("order book is not synchronized")
+	// ErrChecksum is returned when the book after an update does not match the checksum sent by kraken
+	ErrChecksum = errors.New("order book checksum mismatch")
+)
+
+// Snapshot is a copy of the order book at a moment
+// Asks are sorted from the lowest price, bids are sorted from the highest price.
+type Snapshot struct {
+	Pair  string
+	Depth int
+	Asks  []entities.BookLevel
+	Bids  []entities.BookLevel
+	// Seq is increased with every change of the book
+	Seq     uint64
+	Updated time.Time
+}
+
+// BestAsk returns the lowest ask, false if there are no asks
+func (s *Snapshot) BestAsk() (entities.BookLevel, bool) {
+	if len(s.Asks) == 0 {
+		return entities.BookLevel{}, false
+	}
+	return s.Asks[0], true
+}
+
+// BestBid returns the highest bid, false if there are no bids
+func (s *Snapshot) BestBid() (entities.BookLevel, bool) {
+	if len(s.Bids) == 0 {
+		return entities.BookLevel{}, false
+	}
+	return s.Bids[0], true
+}
+
+// Book is an L2 order book of a pair kept up to date with snapshots and updates from kraken
+type Book struct {
+	pair    string
+	depth   int
+	asks    []entities.BookLevel
+	bids    []entities.BookLevel
+	synced  bool
+	seq     uint64
+	updated time.Time
+}
+
+func newBook(pair string, depth int) *Book {
+	return &Book{pair: pair, depth: depth}
+}
+
+// apply applies the snapshot or the update to the book and verifies checksum of the result
+// Updates are rejected with ErrNotSynced until the book receives a snapshot.
+func (b *Book) apply(u *entities.BookUpdate) error {
+	if u.Snapshot {
+		b.asks = b.asks[:0]
+		b.bids = b.bids[:0]
+	} else if !b.synced {
+		return ErrNotSynced
+	}
+	for _, level := range u.Asks {
+		b.asks = upsert(b.asks, level, false)
+		b.touch(level.Time)
+	}
+	for _, level := range u.Bids {
+		b.bids = upsert(b.bids, level, true)
+		b.touch(level.Time)
+	}
+	// levels which fall out of the subscribed depth are not updated by kraken anymore
+	if len(b.asks) > b.depth {
+		b.asks = b.asks[:b.depth]
+	}
+	if len(b.bids) > b.depth {
+		b.bids = b.bids[:b.depth]
+	}
+	b.synced = true
+	b.seq++
+	if !u.Snapshot {
+		if sum := checksum(b.asks, b.bids); sum != u.Checksum {
+			b.reset()
+			return fmt.Errorf("%w: %s expected %d, got %d", ErrChecksum, b.pair, u.Checksum, sum)
+		}
+	}
+	return nil
+}
+
+func (b *Book) touch(t time.Time) {
+	if t.After(b.updated) {
+		b.updated = t
+	}
+}
+
+// reset removes all levels, the book waits for a new snapshot
+func (b *Book) reset() {
+	b.asks = nil
+	b.bids = nil
+	b.synced = false
+	b.seq++
+}
+
+// snapshot returns a copy of the book
+func (b *Book) snapshot() (*Snapshot, error) {
+	if !b.synced {
+		return nil, ErrNotSynced
+	}
+	return &Snapshot{
+		Pair:    b.pair,
+		Depth:   b.depth,
+		Asks:    append([]entities.BookLevel(nil), b.asks...),
+		Bids:    append([]entities.BookLevel(nil), b.bids...),
+		Seq:     b.seq,
+		Updated: b.updated,
+	}, nil
+}
+
+// upsert replaces the level with the same price, or inserts it keeping levels sorted
+// Levels with zero volume are removed. Bids are sorted in descending order.
+func upsert(levels []entities.BookLevel, level entities.BookLevel, descending bool) []entities.BookLevel {
+	i := sort.Search(len(levels), func(i int) bool {
+		if descending {
+			return levels[i].Price.Cmp(level.Price) <= 0
+		}
+		return levels[i].Price.Cmp(level.Price) >= 0
+	})
+	found := i < len(levels) && levels[i].Price == level.Price
+	switch {
+	case found && level.Volume.IsZero():
+		return append(levels[:i], levels[i+1:]...)
+	case found:
+		levels[i] = level
+	case !level.Volume.IsZero():
+		levels = append(levels, entities.BookLevel{})
+		copy(levels[i+1:], levels[i:])
+		levels[i] = level
+	}
+	return levels
+}
+
+// checksum calculates CRC32 of top levels of the book in the way kraken does
+// Prices and volumes of top asks and then top bids are concatenated without decimal points and leading zeros.
+func checksum(asks, bids []entities.BookLevel) uint32 {
+	var sb strings.Builder
+	for _, side := range [][]entities.BookLevel{asks, bids} {
+		for k, level := range side {
+			if k == checksumLevels {
+				break
+			}
+			sb.WriteString(checksumValue(level.RawPrice))
+			sb.WriteString(checksumValue(level.RawVolume))
+		}
+	}
+	return crc32.ChecksumIEEE([]byte(sb.String()))
+}
+
+func checksumValue(s string) string {
+	return strings.TrimLeft(strings.Replace(s, ".", "", 1), "0")
+}
@@ -0,0 +1,98 @@
+package book
+
+import (
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/entities"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// level creates a level with raw values, as the decoder does
+func level(price, volume string) entities.BookLevel {
+	return entities.BookLevel{Price: decimal.MustParse(price), Volume: decimal.MustParse(volume), RawPrice: price, RawVolume: volume}
+}
+
+// prices returns prices of levels in their order
+func prices(levels []entities.BookLevel) []string {
+	var result []string
+	for _, l := range levels {
+		result = append(result, l.RawPrice)
+	}
+	return result
+}
+
+func TestBook_apply(t *testing.T) {
+	b := newBook("XBT/EUR", 3)
+	update := &entities.BookUpdate{Pair: "XBT/EUR", Depth: 3, Asks: []entities.BookLevel{level("101.0", "1.0")}}
+	if err := b.apply(update); !errors.Is(err, ErrNotSynced) {
+		t.Fatalf("apply() before snapshot error = %v, want ErrNotSynced", err)
+	}
+	snapshot := &entities.BookUpdate{
+		Pair:     "XBT/EUR",
+		Depth:    3,
+		Snapshot: true,
+		Asks:     []entities.BookLevel{level("101.0", "1.0"), level("102.0", "2.0"), level("103.0", "3.0")},
+		Bids:     []entities.BookLevel{level("99.0", "1.0"), level("98.0", "2.0")},
+	}
+	if err := b.apply(snapshot); err != nil {
+		t.Fatalf("apply() snapshot error = %v", err)
+	}
+	// new best ask pushes the last ask out of the depth, the best bid is removed, a new bid is inserted
+	asks := []entities.BookLevel{level("100.5", "1.0"), level("101.0", "1.0"), level("102.0", "2.0")}
+	bids := []entities.BookLevel{level("98.5", "0.5"), level("98.0", "2.0")}
+	update = &entities.BookUpdate{
+		Pair:     "XBT/EUR",
+		Depth:    3,
+		Asks:     []entities.BookLevel{level("100.5", "1.0")},
+		Bids:     []entities.BookLevel{level("99.0", "0.0"), level("98.5", "0.5")},
+		Checksum: checksum(asks, bids),
+	}
+	if err := b.apply(update); err != nil {
+		t.Fatalf("apply() update error = %v", err)
+	}
+	s, err := b.snapshot()
+	if err != nil {
+		t.Fatalf("snapshot() error = %v", err)
+	}
+	if !reflect.DeepEqual(s.Asks, asks) || !reflect.DeepEqual(s.Bids, bids) {
+		t.Errorf("snapshot() asks %v, bids %v, want asks %v, bids %v", prices(s.Asks), prices(s.Bids), prices(asks), prices(bids))
+	}
+	if best, ok := s.BestBid(); !ok || best.RawPrice != "98.5" {
+		t.Errorf("BestBid() = %v, %v, want 98.5", best, ok)
+	}
+	update = &entities.BookUpdate{Pair: "XBT/EUR", Depth: 3, Bids: []entities.BookLevel{level("98.5", "0.7")}, Checksum: 1}
+	if err := b.apply(update); !errors.Is(err, ErrChecksum) {
+		t.Fatalf("apply() with wrong checksum error = %v, want ErrChecksum", err)
+	}
+	if _, err := b.snapshot(); !errors.Is(err, ErrNotSynced) {
+		t.Errorf("snapshot() after checksum mismatch error = %v, want ErrNotSynced", err)
+	}
+}
+
+func Test_checksumValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"0.05005", "5005"},
+		{"0.00000500", "500"},
+		{"5541.30000", "554130000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := checksumValue(tt.value); got != tt.want {
+				t.Errorf("checksumValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_checksum(t *testing.T) {
+	asks := []entities.BookLevel{level("0.05005", "0.00000500"), level("0.05010", "0.00000500")}
+	bids := []entities.BookLevel{level("0.05000", "0.00000500")}
+	// crc32 of "5005500" + "5010500" + "5000500"
+	if got, want := checksum(asks, bids), uint32(1725113685); got != want {
+		t.Errorf("checksum() = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,159 @@
+package book
+
+import (
+	"bth-trader/internal/entities"
+	"bth-trader/internal/market"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// DefaultDepth is number of price levels of books if the depth is not set
+const DefaultDepth = 10
+
+// ErrNotMaintained is returned for a book which is not acquired by anybody
+var ErrNotMaintained = errors.New("order book is not maintained")
+
+type key struct {
+	pair  string
+	depth int
+}
+
+func (k key) topic() market.Topic {
+	return market.Topic{Channel: market.ChannelBook, Pair: k.pair, Depth: k.depth}
+}
+
+// entry is a maintained book and a channel which is closed when the book changes
+type entry struct {
+	book    *Book
+	changed chan struct{}
+}
+
+// Books maintains order books while they are acquired by gRPC streams or other components
+// It is an observer of book updates decoded from the public channels of kraken.
+type Books struct {
+	subs    *market.Subscriptions
+	entries map[key]*entry
+	mu      *sync.Mutex
+}
+
+// NewBooks creates an empty set of books, kraken is subscribed to books through subs
+func NewBooks(subs *market.Subscriptions) *Books {
+	return &Books{
+		subs:    subs,
+		entries: make(map[key]*entry),
+		mu:      &sync.Mutex{},
+	}
+}
+
+// Acquire starts maintaining the book of the pair with depth levels on each side
+// The book is available after kraken sends its snapshot. Every successful call should be followed by Release.
+func (b *Books) Acquire(pair string, depth int) error {
+	k := key{pair: pair, depth: depth}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.subs.Acquire(k.topic()); err != nil {
+		return err
+	}
+	if _, ok := b.entries[k]; !ok {
+		b.entries[k] = &entry{book: newBook(pair, depth), changed: make(chan struct{})}
+	}
+	return nil
+}
+
+// Release stops maintaining the book if nobody else uses it
+func (b *Books) Release(pair string, depth int) error {
+	k := key{pair: pair, depth: depth}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	err := b.subs.Release(k.topic())
+	if e, ok := b.entries[k]; ok && b.subs.Listeners(k.topic()) == 0 {
+		close(e.changed)
+		delete(b.entries, k)
+	}
+	return err
+}
+
+// Notify applies the update to the book, the book is synchronized again if its checksum does not match
+// A new snapshot is requested after the lock is released, so a slow connection does not block other books.
+func (b *Books) Notify(u *entities.BookUpdate) {
+	k := key{pair: u.Pair, depth: u.Depth}
+	if err := b.apply(k, u); errors.Is(err, ErrChecksum) {
+		log.Printf("%v, requesting new snapshot", err)
+		if err := b.subs.Resubscribe(k.topic()); err != nil {
+			log.Printf("cannot resubscribe to order book: %v", err)
+		}
+	}
+}
+
+// apply applies the update to the book and notifies watchers of the book
+func (b *Books) apply(k key, u *entities.BookUpdate) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	e, ok := b.entries[k]
+	if !ok {
+		// the update was sent before kraken received unsubscribe
+		return nil
+	}
+	err := e.book.apply(u)
+	if errors.Is(err, ErrNotSynced) {
+		// updates sent before the new snapshot are not related to it
+		return nil
+	}
+	e.changed = notify(e.changed)
+	return err
+}
+
+// Reset drops data of all books, they wait for new snapshots
+// It should be called when the connection to kraken is lost, since updates sent meanwhile are missed.
+func (b *Books) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, e := range b.entries {
+		e.book.reset()
+		e.changed = notify(e.changed)
+	}
+}
+
+// notify closes the channel to wake up watchers and returns a new one
+func notify(changed chan struct{}) chan struct{} {
+	close(changed)
+	return make(chan struct{})
+}
+
+// Snapshot returns a copy of the book
+// Returns ErrNotMaintained if the book is not acquired and ErrNotSynced if the book is waiting for a snapshot.
+func (b *Books) Snapshot(pair string, depth int) (*Snapshot, error) {
+	s, _, err := b.Watch(pair, depth)
+	return s, err
+}
+
+// Watch returns a copy of the book and a channel which is closed when the book changes or is released
+// The snapshot is nil with ErrNotSynced while the book is waiting for data from kraken.
+func (b *Books) Watch(pair string, depth int) (*Snapshot, <-chan struct{}, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	e, ok := b.entries[key{pair: pair, depth: depth}]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s with depth %d", ErrNotMaintained, pair, depth)
+	}
+	s, err := e.book.snapshot()
+	return s, e.changed, err
+}
+
+// Wait waits until the acquired book is synchronized and returns its copy
+func (b *Books) Wait(ctx context.Context, pair string, depth int) (*Snapshot, error) {
+	for {
+		s, changed, err := b.Watch(pair, depth)
+		if !errors.Is(err, ErrNotSynced) {
+			return s, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
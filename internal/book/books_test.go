@@ -0,0 +1,109 @@
+package book
+
+import (
+	"bth-trader/internal/entities"
+	"bth-trader/internal/kraken"
+	"bth-trader/internal/market"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"testing"
+	"time"
+)
+
+type mockSubscriber struct {
+	sent []kraken.SubMessage
+	// books is checked to be unlocked while messages are sent
+	books *Books
+	// locked is set if a message was sent while books were locked
+	locked bool
+}
+
+func (m *mockSubscriber) Subscribe(sub kraken.SubMessage) error {
+	m.sent = append(m.sent, sub)
+	if m.books != nil && sub.Event == "subscribe" {
+		if m.books.mu.TryLock() {
+			m.books.mu.Unlock()
+		} else {
+			m.locked = true
+		}
+	}
+	return nil
+}
+
+func TestBooks(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	ws := &mockSubscriber{}
+	books := NewBooks(market.NewSubscriptions(ws))
+	if _, err := books.Snapshot("XBT/EUR", 10); !errors.Is(err, ErrNotMaintained) {
+		t.Fatalf("Snapshot() of unknown book error = %v, want ErrNotMaintained", err)
+	}
+	if err := books.Acquire("XBT/EUR", 10); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if _, err := books.Snapshot("XBT/EUR", 10); !errors.Is(err, ErrNotSynced) {
+		t.Fatalf("Snapshot() before data error = %v, want ErrNotSynced", err)
+	}
+	go books.Notify(&entities.BookUpdate{
+		Pair:     "XBT/EUR",
+		Depth:    10,
+		Snapshot: true,
+		Asks:     []entities.BookLevel{level("101.0", "1.0")},
+		Bids:     []entities.BookLevel{level("99.0", "1.0")},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	s, err := books.Wait(ctx, "XBT/EUR", 10)
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if best, ok := s.BestAsk(); !ok || best.RawPrice != "101.0" {
+		t.Errorf("BestAsk() = %v, %v, want 101.0", best, ok)
+	}
+	// a wrong checksum makes the book wait for a new snapshot
+	ws.books = books
+	books.Notify(&entities.BookUpdate{Pair: "XBT/EUR", Depth: 10, Asks: []entities.BookLevel{level("101.0", "2.0")}, Checksum: 1})
+	if _, err := books.Snapshot("XBT/EUR", 10); !errors.Is(err, ErrNotSynced) {
+		t.Errorf("Snapshot() after checksum mismatch error = %v, want ErrNotSynced", err)
+	}
+	events := []string{}
+	for _, m := range ws.sent {
+		events = append(events, m.Event)
+	}
+	if len(events) != 3 || events[1] != "unsubscribe" || events[2] != "subscribe" {
+		t.Errorf("sent events %v, want subscribe, unsubscribe, subscribe", events)
+	}
+	if ws.locked {
+		t.Errorf("new snapshot was requested while books were locked")
+	}
+	if err := books.Release("XBT/EUR", 10); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, err := books.Snapshot("XBT/EUR", 10); !errors.Is(err, ErrNotMaintained) {
+		t.Errorf("Snapshot() of released book error = %v, want ErrNotMaintained", err)
+	}
+}
+
+func TestBooks_Reset(t *testing.T) {
+	books := NewBooks(market.NewSubscriptions(&mockSubscriber{}))
+	if err := books.Acquire("XBT/EUR", 10); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	books.Notify(&entities.BookUpdate{Pair: "XBT/EUR", Depth: 10, Snapshot: true, Asks: []entities.BookLevel{level("101.0", "1.0")}})
+	_, changed, err := books.Watch("XBT/EUR", 10)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	books.Reset()
+	select {
+	case <-changed:
+	default:
+		t.Errorf("watchers are not notified about the reset")
+	}
+	if _, err := books.Snapshot("XBT/EUR", 10); !errors.Is(err, ErrNotSynced) {
+		t.Errorf("Snapshot() after reset error = %v, want ErrNotSynced", err)
+	}
+}
@@ -0,0 +1,306 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxDigits is number of significant digits which always fit into int64
+const maxDigits = 18
+
+// maxScale limits the exponent of parsed numbers, so calculations with them stay cheap
+// It is far beyond precision of any asset.
+const maxScale = 40
+
+// ErrSyntax is returned when a string is not a decimal number
+var ErrSyntax = errors.New("invalid decimal")
+
+// ErrRange is returned when a number has more significant digits or a larger exponent than decimals can keep
+var ErrRange = errors.New("decimal out of range")
+
+// Decimal is an exact decimal number with up to 18 significant digits
+// The value is coef * 10^-scale. Values are normalized, so equal numbers are equal structs
+// and can be compared with == and reflect.DeepEqual. The zero value is 0.
+// Results of arithmetic with more than 18 significant digits are rounded half away from zero.
+type Decimal struct {
+	coef  int64
+	scale int32
+}
+
+// Zero is the decimal 0
+var Zero = Decimal{}
+
+// New creates the decimal coef * 10^-scale
+func New(coef int64, scale int32) Decimal {
+	return fromBig(big.NewInt(coef), scale)
+}
+
+// NewFromInt creates the decimal from the integer
+func NewFromInt(v int64) Decimal {
+	return New(v, 0)
+}
+
+// NewFromFloat creates the decimal from the shortest representation of the float
+// Returns ErrSyntax for non-finite values and ErrRange for values which cannot be kept exactly.
+func NewFromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Zero, fmt.Errorf("%w: %v", ErrSyntax, f)
+	}
+	return Parse(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// Parse parses decimal notation, e.g. "-12.3400", optionally with exponent, e.g. "1.5e-5"
+// Numbers are never rounded: ErrRange is returned for more than 18 significant digits
+// or for a number whose exponent exceeds 40 in either direction.
+func Parse(s string) (Decimal, error) {
+	orig := s
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	var exp int64
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		if exp, err = strconv.ParseInt(s[i+1:], 10, 32); err != nil {
+			return Zero, fmt.Errorf("%w: %q", ErrSyntax, orig)
+		}
+		s = s[:i]
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+	digits := intPart + frac
+	if digits == "" {
+		return Zero, fmt.Errorf("%w: %q", ErrSyntax, orig)
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return Zero, fmt.Errorf("%w: %q", ErrSyntax, orig)
+		}
+	}
+	digits = strings.TrimLeft(digits, "0")
+	scale := int64(len(frac)) - exp
+	for strings.HasSuffix(digits, "0") {
+		digits = digits[:len(digits)-1]
+		scale--
+	}
+	if digits == "" {
+		return Zero, nil
+	}
+	if len(digits) > maxDigits {
+		return Zero, fmt.Errorf("%w: %q has more than %d significant digits", ErrRange, orig, maxDigits)
+	}
+	if scale > maxScale || scale < -maxScale {
+		return Zero, fmt.Errorf("%w: exponent of %q is out of range", ErrRange, orig)
+	}
+	return fromDigits(neg, digits, int32(scale)), nil
+}
+
+// MustParse parses the decimal and panics if the string is not a decimal, it is intended for constants
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// fromDigits creates the decimal from decimal digits, digits beyond maxDigits are rounded
+func fromDigits(neg bool, digits string, scale int32) Decimal {
+	digits = strings.TrimLeft(digits, "0")
+	for strings.HasSuffix(digits, "0") {
+		digits = digits[:len(digits)-1]
+		scale--
+	}
+	if digits == "" {
+		return Zero
+	}
+	roundUp := false
+	if len(digits) > maxDigits {
+		roundUp = digits[maxDigits] >= '5'
+		scale -= int32(len(digits) - maxDigits)
+		digits = digits[:maxDigits]
+	}
+	coef, _ := strconv.ParseInt(digits, 10, 64)
+	if roundUp {
+		// 10^18 still fits into int64
+		coef++
+	}
+	if neg {
+		coef = -coef
+	}
+	for coef%10 == 0 {
+		coef /= 10
+		scale--
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+func fromBig(n *big.Int, scale int32) Decimal {
+	if n.IsInt64() {
+		coef := n.Int64()
+		if coef == 0 {
+			return Zero
+		}
+		if coef > -1e18 && coef < 1e18 {
+			for coef%10 == 0 {
+				coef /= 10
+				scale--
+			}
+			return Decimal{coef: coef, scale: scale}
+		}
+	}
+	return fromDigits(n.Sign() < 0, new(big.Int).Abs(n).String(), scale)
+}
+
+// align returns coefficients of both decimals with the same scale
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	x, y := big.NewInt(a.coef), big.NewInt(b.coef)
+	switch {
+	case a.scale > b.scale:
+		y.Mul(y, pow10(a.scale-b.scale))
+		return x, y, a.scale
+	case b.scale > a.scale:
+		x.Mul(x, pow10(b.scale-a.scale))
+	}
+	return x, y, b.scale
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// Add returns d + o
+func (d Decimal) Add(o Decimal) Decimal {
+	x, y, scale := align(d, o)
+	return fromBig(x.Add(x, y), scale)
+}
+
+// Sub returns d - o
+func (d Decimal) Sub(o Decimal) Decimal {
+	x, y, scale := align(d, o)
+	return fromBig(x.Sub(x, y), scale)
+}
+
+// Mul returns d * o
+func (d Decimal) Mul(o Decimal) Decimal {
+	x := big.NewInt(d.coef)
+	return fromBig(x.Mul(x, big.NewInt(o.coef)), d.scale+o.scale)
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: -d.coef, scale: d.scale}
+}
+
+// Cmp compares decimals, returns -1 if d < o, 0 if d == o and 1 if d > o
+func (d Decimal) Cmp(o Decimal) int {
+	if d.scale == o.scale {
+		switch {
+		case d.coef < o.coef:
+			return -1
+		case d.coef > o.coef:
+			return 1
+		}
+		return 0
+	}
+	x, y, _ := align(d, o)
+	return x.Cmp(y)
+}
+
+// Sign returns -1, 0 or 1 for negative, zero or positive decimals
+func (d Decimal) Sign() int {
+	switch {
+	case d.coef < 0:
+		return -1
+	case d.coef > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsZero reports whether the decimal is 0
+func (d Decimal) IsZero() bool {
+	return d.coef == 0
+}
+
+// Decimals returns number of digits after the decimal point
+func (d Decimal) Decimals() int32 {
+	if d.scale < 0 {
+		return 0
+	}
+	return d.scale
+}
+
+// Round rounds the decimal to places after the decimal point, half away from zero
+func (d Decimal) Round(places int32) Decimal {
+	if d.scale <= places {
+		return d
+	}
+	x := big.NewInt(d.coef)
+	div := pow10(d.scale - places)
+	q, r := new(big.Int).QuoRem(x, div, new(big.Int))
+	// |r| * 2 >= div means the remainder is at least a half
+	if r.Abs(r).Mul(r, big.NewInt(2)).Cmp(div) >= 0 {
+		q.Add(q, big.NewInt(int64(d.Sign())))
+	}
+	return fromBig(q, places)
+}
+
+// IsMultipleOf reports whether the decimal is an integer multiple of the positive step
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	if step.Sign() <= 0 {
+		return false
+	}
+	x, y, _ := align(d, step)
+	return new(big.Int).Rem(x, y).Sign() == 0
+}
+
+// Float64 returns the nearest float, it should be used only where exactness is not required
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String formats the decimal in decimal notation without exponent, e.g. "0.00001"
+func (d Decimal) String() string {
+	if d.coef == 0 {
+		return "0"
+	}
+	digits := strconv.FormatInt(d.coef, 10)
+	sign := ""
+	if d.coef < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if d.scale <= 0 {
+		return sign + digits + strings.Repeat("0", int(-d.scale))
+	}
+	if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// MarshalJSON encodes the decimal as a string to keep it exact
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes the decimal from a string or a number, null and empty string are zero
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*d = Zero
+		return nil
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
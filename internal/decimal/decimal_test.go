@@ -0,0 +1,188 @@
+package decimal
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr error
+	}{
+		{in: "0", want: "0"},
+		{in: "0.000", want: "0"},
+		{in: "-0", want: "0"},
+		{in: "12.3400", want: "12.34"},
+		{in: "-12.34", want: "-12.34"},
+		{in: "+5", want: "5"},
+		{in: ".5", want: "0.5"},
+		{in: "5.", want: "5"},
+		{in: "1500", want: "1500"},
+		{in: "0.00000001", want: "0.00000001"},
+		{in: "1.5e-5", want: "0.000015"},
+		{in: "1E3", want: "1000"},
+		{in: "123456789.123456789", want: "123456789.123456789"},
+		{in: "0.000000000000000000000000000000000000001", want: "0.000000000000000000000000000000000000001"},
+		{in: "1e40", want: "10000000000000000000000000000000000000000"},
+		{in: "0e-100000000", want: "0"},
+		{in: "0.1234567890123456789", wantErr: ErrRange},
+		{in: "999999999999999999.9", wantErr: ErrRange},
+		{in: "1e-100000000", wantErr: ErrRange},
+		{in: "1e300000000", wantErr: ErrRange},
+		{in: "0.00000000000000000000000000000000000000001", wantErr: ErrRange},
+		{in: "1e99999999999", wantErr: ErrSyntax},
+		{in: "", wantErr: ErrSyntax},
+		{in: "-", wantErr: ErrSyntax},
+		{in: ".", wantErr: ErrSyntax},
+		{in: "1.2.3", wantErr: ErrSyntax},
+		{in: "1e", wantErr: ErrSyntax},
+		{in: "abc", wantErr: ErrSyntax},
+		{in: "1,5", wantErr: ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal_Equal(t *testing.T) {
+	if MustParse("1.50") != MustParse("1.5") || New(150, 2) != MustParse("1.5") || NewFromInt(0) != Zero {
+		t.Errorf("equal decimals should be equal structs")
+	}
+}
+
+func TestNewFromFloat(t *testing.T) {
+	tests := []struct {
+		f       float64
+		want    string
+		wantErr error
+	}{
+		{f: 0.1, want: "0.1"},
+		{f: 20000.1, want: "20000.1"},
+		{f: 1e30, want: "1e30"},
+		{f: 1e-50, wantErr: ErrRange},
+		{f: 1e50, wantErr: ErrRange},
+		{f: math.Inf(1), wantErr: ErrSyntax},
+		{f: math.NaN(), wantErr: ErrSyntax},
+	}
+	for _, tt := range tests {
+		got, err := NewFromFloat(tt.f)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("NewFromFloat(%v) error = %v, want %v", tt.f, err, tt.wantErr)
+			continue
+		}
+		// the shortest representation of the float is used
+		if err == nil && got != MustParse(tt.want) {
+			t.Errorf("NewFromFloat(%v) = %v, want %v", tt.f, got, tt.want)
+		}
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{name: "add", got: MustParse("0.1").Add(MustParse("0.2")), want: "0.3"},
+		{name: "add different scales", got: MustParse("1500").Add(MustParse("0.05")), want: "1500.05"},
+		{name: "sub", got: MustParse("0.3").Sub(MustParse("0.1")), want: "0.2"},
+		{name: "sub to negative", got: MustParse("1").Sub(MustParse("1.25")), want: "-0.25"},
+		{name: "mul", got: MustParse("20000.1").Mul(MustParse("0.00123")), want: "24.600123"},
+		{name: "mul rounds digits", got: MustParse("1.000000001").Mul(MustParse("1.000000001")), want: "1.000000002"},
+		{name: "neg", got: MustParse("1.5").Neg(), want: "-1.5"},
+		{name: "round down", got: MustParse("1.234").Round(2), want: "1.23"},
+		{name: "round half up", got: MustParse("1.235").Round(2), want: "1.24"},
+		{name: "round negative half", got: MustParse("-1.235").Round(2), want: "-1.24"},
+		{name: "round to integer", got: MustParse("0.4").Round(0), want: "0"},
+		{name: "round not needed", got: MustParse("1.2").Round(8), want: "1.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.String() != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal_Cmp(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1", b: "1.0", want: 0},
+		{a: "0.1", b: "0.01", want: 1},
+		{a: "-1", b: "0.5", want: -1},
+		{a: "1500", b: "1499.99", want: 1},
+	}
+	for _, tt := range tests {
+		if got := MustParse(tt.a).Cmp(MustParse(tt.b)); got != tt.want {
+			t.Errorf("Cmp(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestDecimal_IsMultipleOf(t *testing.T) {
+	tests := []struct {
+		v, step string
+		want    bool
+	}{
+		{v: "1500.35", step: "0.05", want: true},
+		{v: "1500.36", step: "0.05", want: false},
+		{v: "20000", step: "0.1", want: true},
+		{v: "0.1", step: "0", want: false},
+		{v: "0", step: "0.1", want: true},
+	}
+	for _, tt := range tests {
+		if got := MustParse(tt.v).IsMultipleOf(MustParse(tt.step)); got != tt.want {
+			t.Errorf("IsMultipleOf(%v, %v) = %v, want %v", tt.v, tt.step, got, tt.want)
+		}
+	}
+}
+
+func TestDecimal_Decimals(t *testing.T) {
+	for in, want := range map[string]int32{"0": 0, "1500": 0, "1.5": 1, "0.00012300": 6} {
+		if got := MustParse(in).Decimals(); got != want {
+			t.Errorf("Decimals(%v) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	var v struct {
+		Price  Decimal `json:"price"`
+		Volume Decimal `json:"volume"`
+		Cost   Decimal `json:"cost"`
+	}
+	if err := json.Unmarshal([]byte(`{"price":"20000.10","volume":0.00123,"cost":""}`), &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if v.Price != MustParse("20000.1") || v.Volume != MustParse("0.00123") || !v.Cost.IsZero() {
+		t.Errorf("Unmarshal() = %+v", v)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != `{"price":"20000.1","volume":"0.00123","cost":"0"}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+	if err := json.Unmarshal([]byte(`{"price":"abc"}`), &v); err == nil {
+		t.Errorf("Unmarshal() expected error")
+	}
+}
@@ -1,28 +1,193 @@
 package entities
 
-import "time"
+import (
+	"bth-trader/internal/decimal"
+	"time"
+)
 
 type Order struct {
 	OrderId string
 	RefId   int
-	// Currently we care only about order status
-	Status string
-	Error  string
+	// ClientOrderId is an id of the order set by the client
+	ClientOrderId string
+	// IdempotencyKey is a key set by the client to place the order only once
+	IdempotencyKey string
+	// Tag is a label set by the client to group orders
+	Tag  string
+	Pair string
+	// Side is buy or sell
+	Side      string
+	OrderType string
+	Status    string
+	Error     string
+	// Volume is ordered volume, VolumeExec is already executed part of it
+	Volume     decimal.Decimal
+	VolumeExec decimal.Decimal
+	Cost       decimal.Decimal
+	Fee        decimal.Decimal
+	AvgPrice   decimal.Decimal
+	// Price and Price2 are prices from the order description
+	Price  decimal.Decimal
+	Price2 decimal.Decimal
+	// LimitPrice and StopPrice are set by the exchange for triggered orders
+	LimitPrice   decimal.Decimal
+	StopPrice    decimal.Decimal
+	OpenTime     time.Time
+	CloseTime    time.Time
+	LastUpdated  time.Time
+	CancelReason string
+	Misc         string
+	OFlags       string
+	// ReplacedId is OrderId of the order which was replaced by this one after editing
+	ReplacedId string
 }
 
-type Balances map[string]float64
+// IsFinished checks if the order is in one of final states
+func (o *Order) IsFinished() bool {
+	return o.Status == "closed" || o.Status == "canceled" || o.Status == "expired"
+}
+
+// Merge updates the order with non-empty fields of the update
+// The exchange sends only changed fields, so empty fields of the update are considered unknown.
+func (o *Order) Merge(u *Order) {
+	mergeString(&o.OrderId, u.OrderId)
+	mergeString(&o.ClientOrderId, u.ClientOrderId)
+	mergeString(&o.IdempotencyKey, u.IdempotencyKey)
+	mergeString(&o.Tag, u.Tag)
+	mergeString(&o.Pair, u.Pair)
+	mergeString(&o.Side, u.Side)
+	mergeString(&o.OrderType, u.OrderType)
+	mergeString(&o.Status, u.Status)
+	mergeString(&o.Error, u.Error)
+	mergeString(&o.CancelReason, u.CancelReason)
+	mergeString(&o.Misc, u.Misc)
+	mergeString(&o.OFlags, u.OFlags)
+	mergeString(&o.ReplacedId, u.ReplacedId)
+	mergeDecimal(&o.Volume, u.Volume)
+	mergeDecimal(&o.VolumeExec, u.VolumeExec)
+	mergeDecimal(&o.Cost, u.Cost)
+	mergeDecimal(&o.Fee, u.Fee)
+	mergeDecimal(&o.AvgPrice, u.AvgPrice)
+	mergeDecimal(&o.Price, u.Price)
+	mergeDecimal(&o.Price2, u.Price2)
+	mergeDecimal(&o.LimitPrice, u.LimitPrice)
+	mergeDecimal(&o.StopPrice, u.StopPrice)
+	mergeTime(&o.OpenTime, u.OpenTime)
+	mergeTime(&o.CloseTime, u.CloseTime)
+	mergeTime(&o.LastUpdated, u.LastUpdated)
+}
+
+func mergeString(dst *string, v string) {
+	if v != "" {
+		*dst = v
+	}
+}
+
+func mergeDecimal(dst *decimal.Decimal, v decimal.Decimal) {
+	if !v.IsZero() {
+		*dst = v
+	}
+}
+
+func mergeTime(dst *time.Time, v time.Time) {
+	if !v.IsZero() {
+		*dst = v
+	}
+}
+
+type Balances map[string]decimal.Decimal
 
 type Trade struct {
-	Cost       float64
-	Fee        float64
-	Margin     float64
+	TradeId    string
+	Cost       decimal.Decimal
+	Fee        decimal.Decimal
+	Margin     decimal.Decimal
 	OrderId    string
 	OrderType  string
 	Pair       string
 	PositionId string
-	Price      float64
+	Price      decimal.Decimal
 	RefId      int
 	Time       time.Time
 	Type       string
-	Volume     float64
+	Volume     decimal.Decimal
+}
+
+// Ticker is a summary of the market of a pair, volumes and prices are taken for the last 24 hours
+type Ticker struct {
+	Pair       string
+	Ask        decimal.Decimal
+	AskVolume  decimal.Decimal
+	Bid        decimal.Decimal
+	BidVolume  decimal.Decimal
+	Last       decimal.Decimal
+	LastVolume decimal.Decimal
+	Volume     decimal.Decimal
+	Vwap       decimal.Decimal
+	Trades     int
+	Low        decimal.Decimal
+	High       decimal.Decimal
+	Open       decimal.Decimal
+}
+
+// PublicTrade is a trade made on the exchange by anyone
+type PublicTrade struct {
+	Pair   string
+	Price  decimal.Decimal
+	Volume decimal.Decimal
+	Time   time.Time
+	// Side is buy or sell
+	Side string
+	// OrderType is market or limit
+	OrderType string
+	Misc      string
+}
+
+// Spread is the best bid and ask of a pair
+type Spread struct {
+	Pair      string
+	Bid       decimal.Decimal
+	Ask       decimal.Decimal
+	BidVolume decimal.Decimal
+	AskVolume decimal.Decimal
+	Time      time.Time
+}
+
+// Candle is OHLC data of a pair for an interval
+type Candle struct {
+	Pair string
+	// Interval is the length of the candle in minutes
+	Interval int
+	Start    time.Time
+	End      time.Time
+	// Updated is the time of the last update of the candle
+	Updated time.Time
+	Open    decimal.Decimal
+	High    decimal.Decimal
+	Low     decimal.Decimal
+	Close   decimal.Decimal
+	Vwap    decimal.Decimal
+	Volume  decimal.Decimal
+	Trades  int
+}
+
+// BookLevel is a price level of an order book
+type BookLevel struct {
+	Price  decimal.Decimal
+	Volume decimal.Decimal
+	Time   time.Time
+	// RawPrice and RawVolume are values as sent by kraken, they are used to calculate checksums of the book
+	RawPrice  string
+	RawVolume string
+}
+
+// BookUpdate is a snapshot or an incremental update of an order book, levels with zero volume are removed
+type BookUpdate struct {
+	Pair     string
+	Depth    int
+	Snapshot bool
+	Asks     []BookLevel
+	Bids     []BookLevel
+	// Checksum is CRC32 of top levels of the book after the update, snapshots have no checksum
+	Checksum uint32
 }
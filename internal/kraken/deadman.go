@@ -0,0 +1,107 @@
+package kraken
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// tokenProvider provides current auth token for websocket requests
+type tokenProvider interface {
+	Token() string
+}
+
+// cancelAfterSender sends cancelAllOrdersAfter requests, implemented by WsClient
+type cancelAfterSender interface {
+	CancelAllAfter(ctx context.Context, msg CancelAllAfterMsg) (Reply, error)
+}
+
+// DeadMansSwitch periodically re-arms cancelAllOrdersAfter on the exchange while the service is healthy
+// If the service crashes or becomes unhealthy the exchange cancels all orders after the timeout.
+type DeadMansSwitch struct {
+	ws      cancelAfterSender
+	tokens  tokenProvider
+	timeout time.Duration
+	healthy func() bool
+	stop    chan struct{}
+	// done is closed when Run returns
+	done chan struct{}
+	// started is true after Run was called, mu guards it
+	started bool
+	mu      *sync.Mutex
+	once    *sync.Once
+}
+
+// NewDeadMansSwitch creates a switch with the countdown timeout
+// Function healthy reports if the switch should be re-armed.
+func NewDeadMansSwitch(ws cancelAfterSender, tokens tokenProvider, timeout time.Duration, healthy func() bool) *DeadMansSwitch {
+	return &DeadMansSwitch{
+		ws:      ws,
+		tokens:  tokens,
+		timeout: timeout,
+		healthy: healthy,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		mu:      &sync.Mutex{},
+		once:    &sync.Once{},
+	}
+}
+
+// Run re-arms the switch every third of the timeout while the service is healthy
+// Blocks the goroutine until Disarm is called, returns at once if the switch is already running or disarmed
+func (d *DeadMansSwitch) Run() {
+	d.mu.Lock()
+	select {
+	case <-d.stop:
+		// the switch was disarmed before it started
+		d.mu.Unlock()
+		return
+	default:
+	}
+	if d.started {
+		d.mu.Unlock()
+		return
+	}
+	d.started = true
+	d.mu.Unlock()
+	defer close(d.done)
+	ticker := time.NewTicker(d.timeout / 3)
+	defer ticker.Stop()
+	for {
+		d.arm()
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// arm restarts the countdown on the exchange if the service is healthy
+func (d *DeadMansSwitch) arm() {
+	if !d.healthy() {
+		log.Printf("service is not healthy, dead man's switch is not re-armed")
+		return
+	}
+	if _, err := d.ws.CancelAllAfter(context.Background(), NewCancelAllAfterMsg(d.timeout, d.tokens.Token())); err != nil {
+		log.Printf("cannot arm dead man's switch: %v", err)
+	}
+}
+
+// Disarm stops re-arming and disables the countdown on the exchange
+// Should be called on clean shutdown. Waits for Run to return if it was started,
+// so a re-arm in progress cannot restart the countdown after it was disabled.
+func (d *DeadMansSwitch) Disarm() error {
+	d.mu.Lock()
+	d.once.Do(func() {
+		close(d.stop)
+	})
+	started := d.started
+	d.mu.Unlock()
+	if started {
+		<-d.done
+	}
+	_, err := d.ws.CancelAllAfter(context.Background(), NewCancelAllAfterMsg(0, d.tokens.Token()))
+	return err
+}
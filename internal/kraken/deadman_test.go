@@ -0,0 +1,107 @@
+package kraken
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type mockCancelAfter struct {
+	mu       sync.Mutex
+	timeouts []int
+	// armDelay delays requests which arm the switch
+	armDelay time.Duration
+}
+
+func (m *mockCancelAfter) CancelAllAfter(_ context.Context, msg CancelAllAfterMsg) (Reply, error) {
+	if msg.Timeout != 0 {
+		time.Sleep(m.armDelay)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.timeouts = append(m.timeouts, msg.Timeout)
+	return Reply{Status: "ok"}, nil
+}
+
+type staticToken string
+
+func (s staticToken) Token() string {
+	return string(s)
+}
+
+func TestDeadMansSwitch_arm(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	tests := []struct {
+		name    string
+		healthy bool
+		want    []int
+	}{
+		{"healthy", true, []int{60}},
+		{"not healthy", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := &mockCancelAfter{}
+			d := NewDeadMansSwitch(ws, staticToken("token"), time.Minute, func() bool { return tt.healthy })
+			d.arm()
+			if !reflect.DeepEqual(ws.timeouts, tt.want) {
+				t.Errorf("arm() sent timeouts %v, want %v", ws.timeouts, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeadMansSwitch_Disarm(t *testing.T) {
+	// the request arming the switch is still in progress when Disarm is called
+	ws := &mockCancelAfter{armDelay: time.Millisecond * 50}
+	d := NewDeadMansSwitch(ws, staticToken("token"), time.Second*3, func() bool { return true })
+	done := make(chan struct{})
+	go func() {
+		d.Run()
+		close(done)
+	}()
+	time.Sleep(time.Millisecond * 10)
+	if err := d.Disarm(); err != nil {
+		t.Fatalf("Disarm() error: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Run() did not stop after Disarm()")
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if want := []int{3, 0}; !reflect.DeepEqual(ws.timeouts, want) {
+		t.Errorf("sent timeouts %v, want %v", ws.timeouts, want)
+	}
+}
+
+func TestDeadMansSwitch_DisarmNotStarted(t *testing.T) {
+	ws := &mockCancelAfter{}
+	d := NewDeadMansSwitch(ws, staticToken("token"), time.Second*3, func() bool { return true })
+	disarmed := make(chan error)
+	go func() {
+		disarmed <- d.Disarm()
+	}()
+	select {
+	case err := <-disarmed:
+		if err != nil {
+			t.Fatalf("Disarm() error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Disarm() blocked without Run()")
+	}
+	// Run after Disarm does not arm the switch again
+	d.Run()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if want := []int{0}; !reflect.DeepEqual(ws.timeouts, want) {
+		t.Errorf("sent timeouts %v, want %v", ws.timeouts, want)
+	}
+}
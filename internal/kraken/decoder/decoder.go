@@ -2,22 +2,32 @@ package decoder
 
 import (
 	"bth-trader/internal/entities"
+	"bth-trader/internal/kraken"
 	"bytes"
 	"encoding/json"
 	"log"
 	"strconv"
+	"strings"
 )
 
 type msgType string
 
 const (
 	msgHeartbeat         msgType = "heartbeat"
+	msgPong              msgType = "pong"
 	msgSubStatus         msgType = "subStatus"
 	msgSysStatus         msgType = "sysStatus"
 	msgAddOrderStatus    msgType = "addOrderStatus"
 	msgCancelOrderStatus msgType = "cancelOrderStatus"
+	msgEditOrderStatus   msgType = "editOrderStatus"
+	msgCancelAllStatus   msgType = "cancelAllStatus"
 	msgOrder             msgType = "order"
 	msgTrade             msgType = "trade"
+	msgTicker            msgType = "ticker"
+	msgPublicTrade       msgType = "publicTrade"
+	msgSpread            msgType = "spread"
+	msgCandle            msgType = "candle"
+	msgBook              msgType = "book"
 	msgUnknown           msgType = "unknown"
 )
 
@@ -26,6 +36,16 @@ type Outputs struct {
 	//HeartBeats chan []byte
 	Orders chan *entities.Order
 	Trades chan *entities.Trade
+	// Tickers, PublicTrades, Spreads, Candles and Books receive market data from public channels
+	Tickers      chan *entities.Ticker
+	PublicTrades chan *entities.PublicTrade
+	Spreads      chan *entities.Spread
+	Candles      chan *entities.Candle
+	Books        chan *entities.BookUpdate
+	// Errors receives error messages reported by the server, e.g. failed subscriptions
+	Errors chan string
+	// Replies is a registry of requests, replies with reqid are routed to it
+	Replies *kraken.Requests
 }
 
 // DecodeStream decodes messages from channel,
@@ -41,16 +61,22 @@ func DecodeStream(in <-chan json.RawMessage, out *Outputs) {
 			continue
 		}
 		switch detectType(rawData) {
-		case msgHeartbeat:
-			//log.Printf("heartbeat")
+		case msgHeartbeat, msgPong:
+			// liveness of the connection is tracked by the websocket client
 		case msgSubStatus:
-			//log.Printf("subscribtion status")
+			reportError(rawData, out)
 		case msgSysStatus:
 			log.Printf("system status")
 		case msgAddOrderStatus:
-			addOrder := parseAddOrderStatus(rawData)
+			reportError(rawData, out)
+			addOrder := parseReply(rawData)
+			resolve(&addOrder, out)
+			if addOrder.RefId == 0 {
+				// the reply is not related to a placed order, e.g. validation of an order
+				continue
+			}
 			order := &entities.Order{
-				RefId: addOrder.ReqId,
+				RefId: addOrder.RefId,
 			}
 			if addOrder.Status != "ok" {
 				order.Status = "error"
@@ -60,9 +86,24 @@ func DecodeStream(in <-chan json.RawMessage, out *Outputs) {
 				order.OrderId = addOrder.TxId
 			}
 			out.Orders <- order
-		case msgCancelOrderStatus:
-			// processing of canceling order status is not a priority
-			//log.Printf("cancel order status: %v", rawData)
+		case msgCancelOrderStatus, msgCancelAllStatus:
+			reportError(rawData, out)
+			reply := parseReply(rawData)
+			resolve(&reply, out)
+		case msgEditOrderStatus:
+			reportError(rawData, out)
+			reply := parseReply(rawData)
+			resolve(&reply, out)
+			if reply.RefId == 0 || reply.Status != "ok" {
+				continue
+			}
+			// the replacement order takes refId of the edited one
+			out.Orders <- &entities.Order{
+				OrderId:    reply.TxId,
+				RefId:      reply.RefId,
+				Status:     "open",
+				ReplacedId: reply.OriginalTxId,
+			}
 		case msgOrder:
 			for _, order := range parseOrders(rawData) {
 				select {
@@ -73,21 +114,102 @@ func DecodeStream(in <-chan json.RawMessage, out *Outputs) {
 				}
 			}
 		case msgTrade:
-			log.Printf("trade")
+			for _, trade := range parseTrades(rawData) {
+				select {
+				case out.Trades <- trade:
+					// the trade is sent to output for further processing
+				default:
+					log.Printf("cannot send trade to output channel, output is full.")
+				}
+			}
+		case msgTicker:
+			ticker, err := parseTicker(rawData)
+			if err != nil {
+				log.Printf("cannot parse ticker: %v", err)
+				continue
+			}
+			sendOrDrop(out.Tickers, ticker, "ticker")
+		case msgPublicTrade:
+			trades, err := parsePublicTrades(rawData)
+			if err != nil {
+				log.Printf("cannot parse public trades: %v", err)
+				continue
+			}
+			for _, trade := range trades {
+				sendOrDrop(out.PublicTrades, trade, "public trade")
+			}
+		case msgSpread:
+			spread, err := parseSpread(rawData)
+			if err != nil {
+				log.Printf("cannot parse spread: %v", err)
+				continue
+			}
+			sendOrDrop(out.Spreads, spread, "spread")
+		case msgCandle:
+			candle, err := parseCandle(rawData)
+			if err != nil {
+				log.Printf("cannot parse candle: %v", err)
+				continue
+			}
+			sendOrDrop(out.Candles, candle, "candle")
+		case msgBook:
+			update, err := parseBook(rawData)
+			if err != nil {
+				log.Printf("cannot parse book: %v", err)
+				continue
+			}
+			sendOrDrop(out.Books, update, "book update")
 		case msgUnknown:
 			log.Printf("unknown on unsupported message: %s", m)
 		}
 	}
 }
 
+// sendOrDrop sends the value to the output unless the output is full
+func sendOrDrop[T any](out chan<- T, v T, what string) {
+	select {
+	case out <- v:
+		// the value is sent to output for further processing
+	default:
+		log.Printf("cannot send %s to output channel, output is full.", what)
+	}
+}
+
+// reportError sends error message of the event to the output if the event has status "error"
+func reportError(rawData any, out *Outputs) {
+	mapData, ok := rawData.(map[string]any)
+	if !ok || mapData["status"] != "error" {
+		return
+	}
+	errMsg, _ := mapData["errorMessage"].(string)
+	log.Printf("%v error: %s", mapData["event"], errMsg)
+	select {
+	case out.Errors <- errMsg:
+	default:
+		// nobody listens for errors or the channel is full
+	}
+}
+
 func detectType(rawData any) msgType {
-	if lstData, ok := rawData.([]any); ok {
+	if lstData, ok := rawData.([]any); ok && len(lstData) >= 2 {
 		if str, ok := lstData[len(lstData)-2].(string); ok {
 			switch str {
 			case "ownTrades":
 				return msgTrade
 			case "openOrders":
 				return msgOrder
+			case "ticker":
+				return msgTicker
+			case "trade":
+				return msgPublicTrade
+			case "spread":
+				return msgSpread
+			}
+			if strings.HasPrefix(str, "ohlc-") {
+				return msgCandle
+			}
+			if strings.HasPrefix(str, "book-") {
+				return msgBook
 			}
 		}
 	}
@@ -96,6 +218,8 @@ func detectType(rawData any) msgType {
 			switch event {
 			case "heartbeat":
 				return msgHeartbeat
+			case "pong":
+				return msgPong
 			case "subscriptionStatus":
 				return msgSubStatus
 			case "systemStatus":
@@ -104,6 +228,10 @@ func detectType(rawData any) msgType {
 				return msgAddOrderStatus
 			case "cancelOrderStatus":
 				return msgCancelOrderStatus
+			case "editOrderStatus":
+				return msgEditOrderStatus
+			case "cancelAllStatus", "cancelAllOrdersAfterStatus":
+				return msgCancelAllStatus
 			}
 		}
 	}
@@ -134,24 +262,10 @@ func parseOrders(rawData any) []*entities.Order {
 				log.Printf("unexpected format of order info: %#v, (from %v)", r, orderMap)
 				continue
 			}
-			// We care only about the ID and the status of an order, and refId if present
-			order := &entities.Order{
-				OrderId: orderId,
-			}
-			if s, ok := info["status"]; ok {
-				order.Status = s.(string)
-			} else {
-				// kraken sends messages to openOrders after each trade on the order,
-				// but without status since only traded volume changing
-				// we shall consider this as "open" status
-				order.Status = "open"
-			}
-			if rawRef, ok := info["userref"]; ok {
-				if refId, err := strconv.Atoi(rawRef.(json.Number).String()); err != nil {
-					log.Printf("cannot parse userref from the order: %v", err)
-				} else {
-					order.RefId = refId
-				}
+			order, err := parseOrder(orderId, info)
+			if err != nil {
+				log.Printf("cannot parse order %s: %v", orderId, err)
+				continue
 			}
 			listOrders = append(listOrders, order)
 		}
@@ -159,24 +273,36 @@ func parseOrders(rawData any) []*entities.Order {
 	return listOrders
 }
 
-type addOrderStatus struct {
-	ReqId  int
-	TxId   string
-	Status string
-	Error  string
-}
-
-func parseAddOrderStatus(rawData any) addOrderStatus {
+// parseReply parses a reply to a request, e.g. addOrderStatus or cancelOrderStatus
+func parseReply(rawData any) kraken.Reply {
 	rawMap := rawData.(map[string]any)
-	result := addOrderStatus{}
-	result.Status = rawMap["status"].(string)
-	reqId := rawMap["reqid"].(json.Number)
-	result.ReqId, _ = strconv.Atoi(reqId.String())
-	if txId, ok := rawMap["txid"]; ok {
-		result.TxId = txId.(string)
+	result := kraken.Reply{}
+	result.Event, _ = rawMap["event"].(string)
+	result.Status, _ = rawMap["status"].(string)
+	if reqId, ok := rawMap["reqid"].(json.Number); ok {
+		result.ReqId, _ = strconv.Atoi(reqId.String())
+	}
+	if txId, ok := rawMap["txid"].(string); ok {
+		result.TxId = txId
+	}
+	if errMsg, ok := rawMap["errorMessage"].(string); ok {
+		result.Error = errMsg
 	}
-	if errMsg, ok := rawMap["errorMessage"]; ok {
-		result.Error = errMsg.(string)
+	if origTxId, ok := rawMap["originaltxid"].(string); ok {
+		result.OriginalTxId = origTxId
+	}
+	if count, ok := rawMap["count"].(json.Number); ok {
+		result.Count, _ = strconv.Atoi(count.String())
 	}
 	return result
 }
+
+// resolve routes the reply to the request waiting for it
+func resolve(reply *kraken.Reply, out *Outputs) {
+	if out.Replies == nil || reply.ReqId == 0 {
+		return
+	}
+	if !out.Replies.Resolve(reply) {
+		log.Printf("nobody waits for reply %s with reqid %d", reply.Event, reply.ReqId)
+	}
+}
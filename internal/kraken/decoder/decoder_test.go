@@ -1,13 +1,17 @@
 package decoder
 
 import (
+	"bth-trader/internal/decimal"
 	"bth-trader/internal/entities"
+	"bth-trader/internal/kraken"
 	"encoding/json"
 	"io"
 	"log"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 //func TestMain(m *testing.M) {
@@ -24,7 +28,14 @@ func TestDecodeStream(t *testing.T) {
 	type testOutput struct {
 		orders []*entities.Order
 		trades []*entities.Trade
+		errors []string
 	}
+	successReplies := kraken.NewRequests()
+	successReplies.Register(112233)
+	errorReplies := kraken.NewRequests()
+	errorReplies.Register(223344)
+	editReplies := kraken.NewRequests()
+	editReplies.Register(334455)
 	tests := []struct {
 		name       string
 		args       args
@@ -51,10 +62,10 @@ func TestDecodeStream(t *testing.T) {
 		},
 		{
 			name:       "addOrder success",
-			inMessages: []json.RawMessage{json.RawMessage(`{"event":"addOrderStatus", "reqid": 112233, "status": "ok", "txid": "ABCDEF-ABCD2-ABCDE3", "descr": "test order"}`)},
+			inMessages: []json.RawMessage{json.RawMessage(`{"event":"addOrderStatus", "reqid": 1, "status": "ok", "txid": "ABCDEF-ABCD2-ABCDE3", "descr": "test order"}`)},
 			args: args{
 				make(chan json.RawMessage, 6),
-				&Outputs{Orders: make(chan *entities.Order, 100), Trades: make(chan *entities.Trade, 100)},
+				&Outputs{Orders: make(chan *entities.Order, 100), Trades: make(chan *entities.Trade, 100), Replies: successReplies},
 			},
 			wantOut: testOutput{orders: []*entities.Order{
 				{OrderId: "ABCDEF-ABCD2-ABCDE3", RefId: 112233, Status: "open"},
@@ -62,15 +73,26 @@ func TestDecodeStream(t *testing.T) {
 		},
 		{
 			name:       "addOrder error",
-			inMessages: []json.RawMessage{json.RawMessage(`{"event":"addOrderStatusStatus", "reqid": 223344, "status": "error", "errorMessage": "TestErrorMsg"}`)},
+			inMessages: []json.RawMessage{json.RawMessage(`{"event":"addOrderStatusStatus", "reqid": 1, "status": "error", "errorMessage": "TestErrorMsg"}`)},
 			args: args{
 				make(chan json.RawMessage, 6),
-				&Outputs{Orders: make(chan *entities.Order, 100), Trades: make(chan *entities.Trade, 100)},
+				&Outputs{Orders: make(chan *entities.Order, 100), Trades: make(chan *entities.Trade, 100), Replies: errorReplies},
 			},
 			wantOut: testOutput{orders: []*entities.Order{
 				{RefId: 223344, Status: "error", Error: "TestErrorMsg"},
 			}},
 		},
+		{
+			name:       "editOrder success",
+			inMessages: []json.RawMessage{json.RawMessage(`{"descr":"order edited price = 9000.00000000","event":"editOrderStatus","originaltxid":"O65KZW-J4AW3-VFS74A","reqid":1,"status":"ok","txid":"OTI672-HJFAO-XOIPPK"}`)},
+			args: args{
+				make(chan json.RawMessage, 6),
+				&Outputs{Orders: make(chan *entities.Order, 100), Trades: make(chan *entities.Trade, 100), Replies: editReplies},
+			},
+			wantOut: testOutput{orders: []*entities.Order{
+				{OrderId: "OTI672-HJFAO-XOIPPK", RefId: 334455, Status: "open", ReplacedId: "O65KZW-J4AW3-VFS74A"},
+			}},
+		},
 		{
 			name:       "order open",
 			inMessages: []json.RawMessage{json.RawMessage(`[[{"ABCDEF-ABCD2-ABCDE3":{"avg_price":"0.00000","cost":"0.00000","descr":{"close":null,"leverage":null,"order":"buy 0.90101951 XBT/EUR @ limit 23302.00000","ordertype":"limit","pair":"XBT/EUR","price":"23302.00000","price2":"0.00000","type":"buy"},"expiretm":null,"fee":"0.00000","limitprice":"0.00000","misc":"","oflags":"fciq","opentm":"1660000011.012345","refid":123456,"starttm":null,"status":"open","stopprice":"0.00000","timeinforce":"GTC","userref":123456,"vol":"0.90101951","vol_exec":"0.00000000"}}],"openOrders",{"sequence":1}]`)},
@@ -79,7 +101,10 @@ func TestDecodeStream(t *testing.T) {
 				&Outputs{Orders: make(chan *entities.Order, 100), Trades: make(chan *entities.Trade, 100)},
 			},
 			wantOut: testOutput{orders: []*entities.Order{
-				{OrderId: "ABCDEF-ABCD2-ABCDE3", RefId: 123456, Status: "open"},
+				{
+					OrderId: "ABCDEF-ABCD2-ABCDE3", RefId: 123456, Pair: "XBT/EUR", Side: "buy", OrderType: "limit", Status: "open",
+					Volume: decimal.MustParse("0.90101951"), Price: decimal.MustParse("23302"), OpenTime: time.Unix(1660000011, 12345000), OFlags: "fciq",
+				},
 			}},
 		},
 		{
@@ -90,17 +115,35 @@ func TestDecodeStream(t *testing.T) {
 				&Outputs{Orders: make(chan *entities.Order, 100), Trades: make(chan *entities.Trade, 100)},
 			},
 			wantOut: testOutput{orders: []*entities.Order{
-				{OrderId: "ABCDEF-ABCD2-ABCDE4", RefId: 778899, Status: "canceled"},
+				{
+					OrderId: "ABCDEF-ABCD2-ABCDE4", RefId: 778899, Status: "canceled", CancelReason: "User requested",
+					LastUpdated: time.Unix(1650000019, 12345000), CloseTime: time.Unix(1650000019, 12345000),
+				},
 			}},
 		},
+		{
+			name:       "subscription error",
+			inMessages: []json.RawMessage{json.RawMessage(`{"errorMessage":"EAPI:Invalid token","event":"subscriptionStatus","status":"error","subscription":{"name":"openOrders"}}`)},
+			args: args{
+				make(chan json.RawMessage, 6),
+				&Outputs{Orders: make(chan *entities.Order, 100), Trades: make(chan *entities.Trade, 100), Errors: make(chan string, 100)},
+			},
+			wantOut: testOutput{errors: []string{"EAPI:Invalid token"}},
+		},
 		{
 			name:       "trade",
-			inMessages: []json.RawMessage{json.RawMessage(`[[{"TTTTTT-AAAA1-EEEEE1":{"cost":"100.14230","fee":"0.16023","margin":"0.00000","ordertxid":"OZXDAA-A10A1-0ABCDE","ordertype":"limit","pair":"ETH/EUR","postxid":"TABCDE-ABCD1-ABCDE2","price":"1728.40000","time":"1650000011.061588","type":"sell","vol":"0.05793931"}}],"ownTrades",{"sequence":1}]`)},
+			inMessages: []json.RawMessage{json.RawMessage(`[[{"TTTTTT-AAAA1-EEEEE1":{"cost":"100.14230","fee":"0.16023","margin":"0.00000","ordertxid":"OZXDAA-A10A1-0ABCDE","ordertype":"limit","pair":"ETH/EUR","postxid":"TABCDE-ABCD1-ABCDE2","price":"1728.40000","time":"1650000011.061588","type":"sell","vol":"0.05793931","userref":778899}}],"ownTrades",{"sequence":1}]`)},
 			args: args{
 				make(chan json.RawMessage, 6),
 				&Outputs{Orders: make(chan *entities.Order, 100), Trades: make(chan *entities.Trade, 100)},
 			},
-			wantOut: testOutput{},
+			wantOut: testOutput{trades: []*entities.Trade{
+				{
+					TradeId: "TTTTTT-AAAA1-EEEEE1", Cost: decimal.MustParse("100.1423"), Fee: decimal.MustParse("0.16023"), OrderId: "OZXDAA-A10A1-0ABCDE", OrderType: "limit",
+					Pair: "ETH/EUR", PositionId: "TABCDE-ABCD1-ABCDE2", Price: decimal.MustParse("1728.4"), RefId: 778899,
+					Time: time.Unix(1650000011, 61588000), Type: "sell", Volume: decimal.MustParse("0.05793931"),
+				},
+			}},
 		},
 	}
 	log.SetOutput(io.Discard)
@@ -122,6 +165,16 @@ func TestDecodeStream(t *testing.T) {
 			for t := range tt.args.out.Trades {
 				gotTrades = append(gotTrades, t)
 			}
+			var gotErrors []string
+			if tt.args.out.Errors != nil {
+				close(tt.args.out.Errors)
+				for e := range tt.args.out.Errors {
+					gotErrors = append(gotErrors, e)
+				}
+			}
+			if !reflect.DeepEqual(gotErrors, tt.wantOut.errors) {
+				t.Errorf("DecodeStream() expected output.Errors = %v, got %v", tt.wantOut.errors, gotErrors)
+			}
 			if !reflect.DeepEqual(gotTrades, tt.wantOut.trades) {
 				t.Errorf("DecodeStream() expected output.Trades = %v, got %v", tt.wantOut.trades, gotTrades)
 				for k, o := range tt.wantOut.trades {
@@ -154,6 +207,11 @@ func Test_detectType(t *testing.T) {
 			args: args{map[string]any{"event": "heartbeat"}},
 			want: msgHeartbeat,
 		},
+		{
+			name: "pong",
+			args: args{map[string]any{"event": "pong", "reqid": 42}},
+			want: msgPong,
+		},
 		{
 			name: "system-status",
 			args: args{map[string]any{"connectionID": "101", "event": "systemStatus", "status": "online", "version": "1.9.0"}},
@@ -184,6 +242,21 @@ func Test_detectType(t *testing.T) {
 			args: args{map[string]any{"channelName": "cancelOrder", "event": "cancelOrderStatus", "status": "error", "errorMessage": "test error"}},
 			want: msgCancelOrderStatus,
 		},
+		{
+			name: "edit-order",
+			args: args{map[string]any{"event": "editOrderStatus", "status": "ok", "txid": "OTI672-HJFAO-XOIPPK"}},
+			want: msgEditOrderStatus,
+		},
+		{
+			name: "cancel-all",
+			args: args{map[string]any{"event": "cancelAllStatus", "status": "ok", "count": 2}},
+			want: msgCancelAllStatus,
+		},
+		{
+			name: "cancel-all-after",
+			args: args{map[string]any{"event": "cancelAllOrdersAfterStatus", "status": "ok", "triggerTime": "2022-08-01T12:01:00Z"}},
+			want: msgCancelAllStatus,
+		},
 		{
 			name: "order",
 			args: args{[]any{[]any{map[string]any{"1ABCDE-FGHIJ-12345A": map[string]any{"avg_price": "0.00000", "cost": "0.00000", "descr": map[string]interface{}{"close": interface{}(nil), "leverage": interface{}(nil), "order": "buy 0.90011223 XBT/EUR @ limit 23302.00000", "ordertype": "limit", "pair": "XBT/EUR", "price": "23302.00000", "price2": "0.00000", "type": "buy"}, "expiretm": interface{}(nil), "fee": "0.00000", "limitprice": "0.00000", "misc": "", "oflags": "fciq", "opentm": "1650000011.012345", "refid": 123456, "starttm": interface{}(nil), "status": "open", "stopprice": "0.00000", "timeinforce": "GTC", "userref": "123456", "vol": "0.90011223", "vol_exec": "0.00000000"}}}, "openOrders", map[string]any{"sequence": "1"}}},
@@ -199,6 +272,31 @@ func Test_detectType(t *testing.T) {
 			args: args{[]any{[]any{map[string]any{"TTTTTT-AAAA1-EEEEE1": map[string]any{"cost": "100.14230", "fee": "0.16023", "margin": "0.00000", "ordertxid": "OZXDAA-A10A1-0ABCDE", "ordertype": "limit", "pair": "ETH/EUR", "postxid": "TABCDE-ABCD1-ABCDE2", "price": "1728.40000", "time": "1650000011.061588", "type": "sell", "vol": "0.05793931"}}}, "ownTrades", map[string]any{"sequence": "1"}}},
 			want: msgTrade,
 		},
+		{
+			name: "ticker",
+			args: args{[]any{340, map[string]any{"a": []any{"5525.40000", 1, "1.000"}}, "ticker", "XBT/EUR"}},
+			want: msgTicker,
+		},
+		{
+			name: "public trade",
+			args: args{[]any{337, []any{[]any{"5541.20000", "0.15850568", "1534614057.321597", "s", "l", ""}}, "trade", "XBT/EUR"}},
+			want: msgPublicTrade,
+		},
+		{
+			name: "spread",
+			args: args{[]any{338, []any{"5698.40000", "5700.00000", "1542057299.545897", "1.01234567", "0.98765432"}, "spread", "XBT/EUR"}},
+			want: msgSpread,
+		},
+		{
+			name: "candle",
+			args: args{[]any{42, []any{"1542057314.748456", "1542057360.435743"}, "ohlc-5", "XBT/EUR"}},
+			want: msgCandle,
+		},
+		{
+			name: "book",
+			args: args{[]any{0, map[string]any{"a": []any{}}, map[string]any{"b": []any{}, "c": "974942666"}, "book-10", "XBT/EUR"}},
+			want: msgBook,
+		},
 		{
 			name: "unknown",
 			args: args{map[string]any{"channelName": "something", "event": "unexpected", "key": "value"}},
@@ -213,3 +311,222 @@ func Test_detectType(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseReply(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    kraken.Reply
+	}{
+		{
+			name:    "add order",
+			message: `{"event":"addOrderStatus","reqid":12,"status":"ok","txid":"ABCDEF-ABCD2-ABCDE3","descr":"test order"}`,
+			want:    kraken.Reply{Event: "addOrderStatus", ReqId: 12, Status: "ok", TxId: "ABCDEF-ABCD2-ABCDE3"},
+		},
+		{
+			name:    "cancel order error",
+			message: `{"event":"cancelOrderStatus","reqid":13,"status":"error","errorMessage":"EOrder:Unknown order"}`,
+			want:    kraken.Reply{Event: "cancelOrderStatus", ReqId: 13, Status: "error", Error: "EOrder:Unknown order"},
+		},
+		{
+			name:    "cancel all",
+			message: `{"count":2,"event":"cancelAllStatus","reqid":14,"status":"ok"}`,
+			want:    kraken.Reply{Event: "cancelAllStatus", ReqId: 14, Status: "ok", Count: 2},
+		},
+		{
+			name:    "without reqid",
+			message: `{"event":"cancelOrderStatus","status":"ok"}`,
+			want:    kraken.Reply{Event: "cancelOrderStatus", Status: "ok"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := json.NewDecoder(strings.NewReader(tt.message))
+			d.UseNumber()
+			var rawData any
+			if err := d.Decode(&rawData); err != nil {
+				t.Fatal(err)
+			}
+			if got := parseReply(rawData); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseReply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     any
+		want    time.Time
+		wantErr bool
+	}{
+		{"fraction", "1650000011.061588", time.Unix(1650000011, 61588000), false},
+		{"seconds", "1650000011", time.Unix(1650000011, 0), false},
+		{"number", json.Number("1650000011.5"), time.Unix(1650000011, 500000000), false},
+		{"empty", nil, time.Time{}, false},
+		{"wrong", "yesterday", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTime(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// decodeMessage decodes the message in the same way as DecodeStream does
+func decodeMessage(t *testing.T, message string) any {
+	t.Helper()
+	d := json.NewDecoder(strings.NewReader(message))
+	d.UseNumber()
+	var rawData any
+	if err := d.Decode(&rawData); err != nil {
+		t.Fatal(err)
+	}
+	return rawData
+}
+
+func Test_parseTicker(t *testing.T) {
+	message := `[340,{"a":["5525.40000",1,"1.000"],"b":["5525.10000",1,"2.500"],"c":["5525.10000","0.00398963"],` +
+		`"v":["2634.11501494","3591.17907851"],"p":["5631.44067","5653.78939"],"t":[11493,16267],` +
+		`"l":["5505.00000","5500.00000"],"h":["5783.00000","5790.00000"],"o":["5760.70000","5763.40000"]},"ticker","XBT/EUR"]`
+	want := &entities.Ticker{
+		Pair:       "XBT/EUR",
+		Ask:        decimal.MustParse("5525.4"),
+		AskVolume:  decimal.MustParse("1"),
+		Bid:        decimal.MustParse("5525.1"),
+		BidVolume:  decimal.MustParse("2.5"),
+		Last:       decimal.MustParse("5525.1"),
+		LastVolume: decimal.MustParse("0.00398963"),
+		Volume:     decimal.MustParse("3591.17907851"),
+		Vwap:       decimal.MustParse("5653.78939"),
+		Trades:     16267,
+		Low:        decimal.MustParse("5500"),
+		High:       decimal.MustParse("5790"),
+		Open:       decimal.MustParse("5763.4"),
+	}
+	got, err := parseTicker(decodeMessage(t, message))
+	if err != nil {
+		t.Fatalf("parseTicker() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTicker() = %+v, want %+v", got, want)
+	}
+	if _, err := parseTicker(decodeMessage(t, `[340,{"a":["5525.40000"]},"ticker","XBT/EUR"]`)); err == nil {
+		t.Errorf("parseTicker() expected error for incomplete ticker")
+	}
+}
+
+func Test_parsePublicTrades(t *testing.T) {
+	message := `[337,[["5541.20000","0.15850568","1534614057.321597","s","l",""],` +
+		`["6060.00000","0.02455000","1534614057.324998","b","m","x"]],"trade","XBT/EUR"]`
+	want := []*entities.PublicTrade{
+		{Pair: "XBT/EUR", Price: decimal.MustParse("5541.2"), Volume: decimal.MustParse("0.15850568"), Time: time.Unix(1534614057, 321597000), Side: "sell", OrderType: "limit"},
+		{Pair: "XBT/EUR", Price: decimal.MustParse("6060"), Volume: decimal.MustParse("0.02455"), Time: time.Unix(1534614057, 324998000), Side: "buy", OrderType: "market", Misc: "x"},
+	}
+	got, err := parsePublicTrades(decodeMessage(t, message))
+	if err != nil {
+		t.Fatalf("parsePublicTrades() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePublicTrades() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_parseSpread(t *testing.T) {
+	message := `[338,["5698.40000","5700.00000","1542057299.545897","1.01234567","0.98765432"],"spread","XBT/EUR"]`
+	want := &entities.Spread{
+		Pair:      "XBT/EUR",
+		Bid:       decimal.MustParse("5698.4"),
+		Ask:       decimal.MustParse("5700"),
+		BidVolume: decimal.MustParse("1.01234567"),
+		AskVolume: decimal.MustParse("0.98765432"),
+		Time:      time.Unix(1542057299, 545897000),
+	}
+	got, err := parseSpread(decodeMessage(t, message))
+	if err != nil {
+		t.Fatalf("parseSpread() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSpread() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_parseCandle(t *testing.T) {
+	message := `[42,["1542057314.748456","1542057600.000000","3586.70000","3586.90000","3586.60000","3586.80000",` +
+		`"3586.68894","0.03373000",2],"ohlc-5","XBT/EUR"]`
+	want := &entities.Candle{
+		Pair:     "XBT/EUR",
+		Interval: 5,
+		Start:    time.Unix(1542057300, 0),
+		End:      time.Unix(1542057600, 0),
+		Updated:  time.Unix(1542057314, 748456000),
+		Open:     decimal.MustParse("3586.7"),
+		High:     decimal.MustParse("3586.9"),
+		Low:      decimal.MustParse("3586.6"),
+		Close:    decimal.MustParse("3586.8"),
+		Vwap:     decimal.MustParse("3586.68894"),
+		Volume:   decimal.MustParse("0.03373"),
+		Trades:   2,
+	}
+	got, err := parseCandle(decodeMessage(t, message))
+	if err != nil {
+		t.Fatalf("parseCandle() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCandle() = %+v, want %+v", got, want)
+	}
+	if _, err := parseCandle(decodeMessage(t, `[42,["1542057314.748456"],"ohlc-x","XBT/EUR"]`)); err == nil {
+		t.Errorf("parseCandle() expected error for broken channel name")
+	}
+}
+
+func Test_parseBook(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    *entities.BookUpdate
+	}{
+		{
+			name: "snapshot",
+			message: `[0,{"as":[["5541.30000","2.50700000","1534614248.123678"]],` +
+				`"bs":[["5541.20000","1.52900000","1534614248.765567"]]},"book-10","XBT/EUR"]`,
+			want: &entities.BookUpdate{
+				Pair:     "XBT/EUR",
+				Depth:    10,
+				Snapshot: true,
+				Asks:     []entities.BookLevel{{Price: decimal.MustParse("5541.3"), Volume: decimal.MustParse("2.507"), Time: time.Unix(1534614248, 123678000), RawPrice: "5541.30000", RawVolume: "2.50700000"}},
+				Bids:     []entities.BookLevel{{Price: decimal.MustParse("5541.2"), Volume: decimal.MustParse("1.529"), Time: time.Unix(1534614248, 765567000), RawPrice: "5541.20000", RawVolume: "1.52900000"}},
+			},
+		},
+		{
+			name: "update of both sides",
+			message: `[1234,{"a":[["5541.30000","0.00000000","1534614335.345903"]]},` +
+				`{"b":[["5541.20000","1.00000000","1534614335.345903","r"]],"c":"974942666"},"book-25","XBT/EUR"]`,
+			want: &entities.BookUpdate{
+				Pair:     "XBT/EUR",
+				Depth:    25,
+				Asks:     []entities.BookLevel{{Price: decimal.MustParse("5541.3"), Time: time.Unix(1534614335, 345903000), RawPrice: "5541.30000", RawVolume: "0.00000000"}},
+				Bids:     []entities.BookLevel{{Price: decimal.MustParse("5541.2"), Volume: decimal.MustParse("1"), Time: time.Unix(1534614335, 345903000), RawPrice: "5541.20000", RawVolume: "1.00000000"}},
+				Checksum: 974942666,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBook(decodeMessage(t, tt.message))
+			if err != nil {
+				t.Fatalf("parseBook() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseBook() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package decoder
+
+import (
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/entities"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errFormat is returned when a message of a public channel has unexpected structure
+var errFormat = errors.New("unexpected format")
+
+// publicMessage splits a message of a public channel into its data and the pair
+// Messages look like [channelID, data, channelName, pair].
+func publicMessage(rawData any) (any, string, error) {
+	lstData, ok := rawData.([]any)
+	if !ok || len(lstData) < 4 {
+		return nil, "", fmt.Errorf("%w: expected list of 4 elements", errFormat)
+	}
+	pair, _ := lstData[len(lstData)-1].(string)
+	return lstData[1], pair, nil
+}
+
+// parseTicker parses message from ticker channel
+func parseTicker(rawData any) (*entities.Ticker, error) {
+	data, pair, err := publicMessage(rawData)
+	if err != nil {
+		return nil, err
+	}
+	info, ok := data.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected map of ticker values", errFormat)
+	}
+	ticker := &entities.Ticker{Pair: pair}
+	// values of the last 24 hours are second in lists with values of today and of the last 24 hours
+	for _, f := range []struct {
+		key   string
+		index int
+		dst   *decimal.Decimal
+	}{
+		{"a", 0, &ticker.Ask},
+		{"a", 2, &ticker.AskVolume},
+		{"b", 0, &ticker.Bid},
+		{"b", 2, &ticker.BidVolume},
+		{"c", 0, &ticker.Last},
+		{"c", 1, &ticker.LastVolume},
+		{"v", 1, &ticker.Volume},
+		{"p", 1, &ticker.Vwap},
+		{"l", 1, &ticker.Low},
+		{"h", 1, &ticker.High},
+		{"o", 1, &ticker.Open},
+	} {
+		values, _ := info[f.key].([]any)
+		if *f.dst, err = decimalAt(values, f.index); err != nil {
+			return nil, fmt.Errorf("wrong %s: %w", f.key, err)
+		}
+	}
+	trades, _ := info["t"].([]any)
+	if ticker.Trades, err = intAt(trades, 1); err != nil {
+		return nil, fmt.Errorf("wrong t: %w", err)
+	}
+	return ticker, nil
+}
+
+// parsePublicTrades parses message from trade channel
+func parsePublicTrades(rawData any) ([]*entities.PublicTrade, error) {
+	data, pair, err := publicMessage(rawData)
+	if err != nil {
+		return nil, err
+	}
+	rawTrades, ok := data.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected list of trades", errFormat)
+	}
+	var listTrades []*entities.PublicTrade
+	for _, r := range rawTrades {
+		values, ok := r.([]any)
+		if !ok || len(values) < 5 {
+			return nil, fmt.Errorf("%w: expected list of trade values", errFormat)
+		}
+		trade := &entities.PublicTrade{Pair: pair}
+		if trade.Price, err = decimalAt(values, 0); err != nil {
+			return nil, fmt.Errorf("wrong price: %w", err)
+		}
+		if trade.Volume, err = decimalAt(values, 1); err != nil {
+			return nil, fmt.Errorf("wrong volume: %w", err)
+		}
+		if trade.Time, err = parseTime(values[2]); err != nil {
+			return nil, fmt.Errorf("wrong time: %w", err)
+		}
+		switch values[3] {
+		case "b":
+			trade.Side = "buy"
+		case "s":
+			trade.Side = "sell"
+		}
+		switch values[4] {
+		case "m":
+			trade.OrderType = "market"
+		case "l":
+			trade.OrderType = "limit"
+		}
+		if len(values) > 5 {
+			trade.Misc, _ = values[5].(string)
+		}
+		listTrades = append(listTrades, trade)
+	}
+	return listTrades, nil
+}
+
+// parseSpread parses message from spread channel
+func parseSpread(rawData any) (*entities.Spread, error) {
+	data, pair, err := publicMessage(rawData)
+	if err != nil {
+		return nil, err
+	}
+	values, ok := data.([]any)
+	if !ok || len(values) < 5 {
+		return nil, fmt.Errorf("%w: expected list of spread values", errFormat)
+	}
+	spread := &entities.Spread{Pair: pair}
+	for i, dst := range map[int]*decimal.Decimal{0: &spread.Bid, 1: &spread.Ask, 3: &spread.BidVolume, 4: &spread.AskVolume} {
+		if *dst, err = decimalAt(values, i); err != nil {
+			return nil, fmt.Errorf("wrong value #%d: %w", i, err)
+		}
+	}
+	if spread.Time, err = parseTime(values[2]); err != nil {
+		return nil, fmt.Errorf("wrong time: %w", err)
+	}
+	return spread, nil
+}
+
+// parseCandle parses message from ohlc channel, the interval is taken from the channel name, e.g. "ohlc-5"
+func parseCandle(rawData any) (*entities.Candle, error) {
+	data, pair, err := publicMessage(rawData)
+	if err != nil {
+		return nil, err
+	}
+	lstData := rawData.([]any)
+	name, _ := lstData[len(lstData)-2].(string)
+	interval, err := strconv.Atoi(strings.TrimPrefix(name, "ohlc-"))
+	if err != nil {
+		return nil, fmt.Errorf("wrong channel name %q: %w", name, err)
+	}
+	values, ok := data.([]any)
+	if !ok || len(values) < 9 {
+		return nil, fmt.Errorf("%w: expected list of candle values", errFormat)
+	}
+	candle := &entities.Candle{Pair: pair, Interval: interval}
+	if candle.Updated, err = parseTime(values[0]); err != nil {
+		return nil, fmt.Errorf("wrong time: %w", err)
+	}
+	if candle.End, err = parseTime(values[1]); err != nil {
+		return nil, fmt.Errorf("wrong etime: %w", err)
+	}
+	candle.Start = candle.End.Add(-time.Duration(interval) * time.Minute)
+	for i, dst := range []*decimal.Decimal{&candle.Open, &candle.High, &candle.Low, &candle.Close, &candle.Vwap, &candle.Volume} {
+		if *dst, err = decimalAt(values, i+2); err != nil {
+			return nil, fmt.Errorf("wrong value #%d: %w", i+2, err)
+		}
+	}
+	if candle.Trades, err = intAt(values, 8); err != nil {
+		return nil, fmt.Errorf("wrong count: %w", err)
+	}
+	return candle, nil
+}
+
+// decimalAt parses the number at the index of the list
+func decimalAt(values []any, index int) (decimal.Decimal, error) {
+	if index >= len(values) {
+		return decimal.Zero, fmt.Errorf("%w: no value at %d", errFormat, index)
+	}
+	return parseDecimal(values[index])
+}
+
+// intAt parses the integer at the index of the list
+func intAt(values []any, index int) (int, error) {
+	if index >= len(values) {
+		return 0, fmt.Errorf("%w: no value at %d", errFormat, index)
+	}
+	switch v := values[index].(type) {
+	case json.Number:
+		return strconv.Atoi(v.String())
+	case string:
+		return strconv.Atoi(v)
+	}
+	return 0, fmt.Errorf("unexpected type %T", values[index])
+}
+
+// parseBook parses message from book channel, the depth is taken from the channel name, e.g. "book-10"
+// Updates of both sides are sent in separate objects: [channelID, {"a": ...}, {"b": ..., "c": ...}, "book-10", pair].
+func parseBook(rawData any) (*entities.BookUpdate, error) {
+	_, pair, err := publicMessage(rawData)
+	if err != nil {
+		return nil, err
+	}
+	lstData := rawData.([]any)
+	name, _ := lstData[len(lstData)-2].(string)
+	depth, err := strconv.Atoi(strings.TrimPrefix(name, "book-"))
+	if err != nil {
+		return nil, fmt.Errorf("wrong channel name %q: %w", name, err)
+	}
+	update := &entities.BookUpdate{Pair: pair, Depth: depth}
+	for _, r := range lstData[1 : len(lstData)-2] {
+		info, ok := r.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected map of book levels", errFormat)
+		}
+		for key, value := range info {
+			// snapshots contain "as" and "bs" instead of "a" and "b"
+			update.Snapshot = update.Snapshot || key == "as" || key == "bs"
+			var dst *[]entities.BookLevel
+			switch key {
+			case "a", "as":
+				dst = &update.Asks
+			case "b", "bs":
+				dst = &update.Bids
+			case "c":
+				s, _ := value.(string)
+				checksum, err := strconv.ParseUint(s, 10, 32)
+				if err != nil {
+					return nil, fmt.Errorf("wrong checksum: %w", err)
+				}
+				update.Checksum = uint32(checksum)
+				continue
+			default:
+				continue
+			}
+			levels, err := parseBookLevels(value)
+			if err != nil {
+				return nil, fmt.Errorf("wrong %s: %w", key, err)
+			}
+			*dst = append(*dst, levels...)
+		}
+	}
+	return update, nil
+}
+
+// parseBookLevels parses list of levels, a level is a list of price, volume and timestamp
+func parseBookLevels(raw any) ([]entities.BookLevel, error) {
+	rawLevels, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected list of levels", errFormat)
+	}
+	levels := make([]entities.BookLevel, 0, len(rawLevels))
+	for _, r := range rawLevels {
+		values, ok := r.([]any)
+		if !ok || len(values) < 3 {
+			return nil, fmt.Errorf("%w: expected list of level values", errFormat)
+		}
+		var level entities.BookLevel
+		level.RawPrice, _ = values[0].(string)
+		level.RawVolume, _ = values[1].(string)
+		var err error
+		if level.Price, err = decimal.Parse(level.RawPrice); err != nil {
+			return nil, fmt.Errorf("wrong price: %w", err)
+		}
+		if level.Volume, err = decimal.Parse(level.RawVolume); err != nil {
+			return nil, fmt.Errorf("wrong volume: %w", err)
+		}
+		if level.Time, err = parseTime(values[2]); err != nil {
+			return nil, fmt.Errorf("wrong time: %w", err)
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
@@ -0,0 +1,77 @@
+package decoder
+
+import (
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/entities"
+	"fmt"
+	"strconv"
+)
+
+// parseOrder parses info of an order from openOrders channel
+// Kraken sends only changed fields in updates, missing fields are left empty.
+func parseOrder(orderId string, info map[string]any) (*entities.Order, error) {
+	order := &entities.Order{OrderId: orderId}
+	if s, ok := info["status"].(string); ok {
+		order.Status = s
+	} else {
+		// kraken sends messages to openOrders after each trade on the order,
+		// but without status since only traded volume changing
+		// we shall consider this as "open" status
+		order.Status = "open"
+	}
+	if descr, ok := info["descr"].(map[string]any); ok {
+		order.Pair, _ = descr["pair"].(string)
+		order.Side, _ = descr["type"].(string)
+		order.OrderType, _ = descr["ordertype"].(string)
+		var err error
+		if order.Price, err = parseDecimal(descr["price"]); err != nil {
+			return nil, fmt.Errorf("wrong price: %w", err)
+		}
+		if order.Price2, err = parseDecimal(descr["price2"]); err != nil {
+			return nil, fmt.Errorf("wrong price2: %w", err)
+		}
+	}
+	var err error
+	for key, dst := range map[string]*decimal.Decimal{
+		"vol":        &order.Volume,
+		"vol_exec":   &order.VolumeExec,
+		"cost":       &order.Cost,
+		"fee":        &order.Fee,
+		"avg_price":  &order.AvgPrice,
+		"limitprice": &order.LimitPrice,
+		"stopprice":  &order.StopPrice,
+	} {
+		if *dst, err = parseDecimal(info[key]); err != nil {
+			return nil, fmt.Errorf("wrong %s: %w", key, err)
+		}
+	}
+	for key, dst := range map[string]*string{
+		"misc":          &order.Misc,
+		"oflags":        &order.OFlags,
+		"cl_ord_id":     &order.ClientOrderId,
+		"cancel_reason": &order.CancelReason,
+		"reason":        &order.CancelReason,
+	} {
+		if v, ok := info[key].(string); ok && v != "" {
+			*dst = v
+		}
+	}
+	if order.OpenTime, err = parseTime(info["opentm"]); err != nil {
+		return nil, fmt.Errorf("wrong opentm: %w", err)
+	}
+	if order.LastUpdated, err = parseTime(info["lastupdated"]); err != nil {
+		return nil, fmt.Errorf("wrong lastupdated: %w", err)
+	}
+	if order.CloseTime, err = parseTime(info["closetm"]); err != nil {
+		return nil, fmt.Errorf("wrong closetm: %w", err)
+	}
+	if order.CloseTime.IsZero() && order.IsFinished() {
+		order.CloseTime = order.LastUpdated
+	}
+	if rawRef, ok := info["userref"]; ok && rawRef != nil {
+		if order.RefId, err = strconv.Atoi(fmt.Sprint(rawRef)); err != nil {
+			return nil, fmt.Errorf("wrong userref: %w", err)
+		}
+	}
+	return order, nil
+}
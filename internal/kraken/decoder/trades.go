@@ -0,0 +1,119 @@
+package decoder
+
+import (
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/entities"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseTrades parses message from ownTrades channel
+func parseTrades(rawData any) []*entities.Trade {
+	lstData, ok := rawData.([]any)
+	if !ok {
+		log.Printf("unexpected format of trades message, expected list: %v", rawData)
+		return nil
+	}
+	rawTrades, ok := lstData[0].([]any)
+	if !ok {
+		log.Printf("wrong format of message, no list of trades: %T", lstData[0])
+		return nil
+	}
+	var listTrades []*entities.Trade
+	for _, r := range rawTrades {
+		tradeMap, ok := r.(map[string]any)
+		if !ok {
+			log.Printf("unexpected format of trades map: %v", r)
+			continue
+		}
+		for tradeId, r := range tradeMap {
+			info, ok := r.(map[string]any)
+			if !ok {
+				log.Printf("unexpected format of trade info: %#v, (from %v)", r, tradeMap)
+				continue
+			}
+			trade, err := parseTrade(tradeId, info)
+			if err != nil {
+				log.Printf("cannot parse trade %s: %v", tradeId, err)
+				continue
+			}
+			listTrades = append(listTrades, trade)
+		}
+	}
+	return listTrades
+}
+
+func parseTrade(tradeId string, info map[string]any) (*entities.Trade, error) {
+	trade := &entities.Trade{TradeId: tradeId}
+	trade.OrderId, _ = info["ordertxid"].(string)
+	trade.PositionId, _ = info["postxid"].(string)
+	trade.Pair, _ = info["pair"].(string)
+	trade.Type, _ = info["type"].(string)
+	trade.OrderType, _ = info["ordertype"].(string)
+	var err error
+	for key, dst := range map[string]*decimal.Decimal{
+		"cost":   &trade.Cost,
+		"fee":    &trade.Fee,
+		"margin": &trade.Margin,
+		"price":  &trade.Price,
+		"vol":    &trade.Volume,
+	} {
+		if *dst, err = parseDecimal(info[key]); err != nil {
+			return nil, fmt.Errorf("wrong %s: %w", key, err)
+		}
+	}
+	if trade.Time, err = parseTime(info["time"]); err != nil {
+		return nil, fmt.Errorf("wrong time: %w", err)
+	}
+	if rawRef, ok := info["userref"]; ok && rawRef != nil {
+		if trade.RefId, err = strconv.Atoi(fmt.Sprint(rawRef)); err != nil {
+			return nil, fmt.Errorf("wrong userref: %w", err)
+		}
+	}
+	return trade, nil
+}
+
+// parseDecimal parses a number which can be sent as a string or as a number, missing value is zero
+func parseDecimal(raw any) (decimal.Decimal, error) {
+	switch v := raw.(type) {
+	case nil:
+		return decimal.Zero, nil
+	case string:
+		return decimal.Parse(v)
+	case json.Number:
+		return decimal.Parse(v.String())
+	}
+	return decimal.Zero, fmt.Errorf("unexpected type %T", raw)
+}
+
+// parseTime parses unix timestamp with fraction of seconds, e.g. "1650000011.061588"
+func parseTime(raw any) (time.Time, error) {
+	var s string
+	switch v := raw.(type) {
+	case nil:
+		return time.Time{}, nil
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	default:
+		return time.Time{}, fmt.Errorf("unexpected type %T", raw)
+	}
+	secs, frac, _ := strings.Cut(s, ".")
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var nsec int64
+	if frac != "" {
+		frac = (frac + "000000000")[:9]
+		if nsec, err = strconv.ParseInt(frac, 10, 64); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return time.Unix(sec, nsec), nil
+}
@@ -0,0 +1,241 @@
+package kraken
+
+import (
+	"bth-trader/internal/decimal"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrInvalidOrder is returned when parameters of an order are not valid for its type
+var ErrInvalidOrder = errors.New("invalid order")
+
+// Order types supported by the exchange
+const (
+	OrderMarket            = "market"
+	OrderLimit             = "limit"
+	OrderStopLoss          = "stop-loss"
+	OrderStopLossLimit     = "stop-loss-limit"
+	OrderTakeProfit        = "take-profit"
+	OrderTakeProfitLimit   = "take-profit-limit"
+	OrderTrailingStop      = "trailing-stop"
+	OrderTrailingStopLimit = "trailing-stop-limit"
+	OrderSettlePosition    = "settle-position"
+)
+
+// Order flags supported by the exchange
+const (
+	FlagPost  = "post"
+	FlagFcib  = "fcib"
+	FlagFciq  = "fciq"
+	FlagNompp = "nompp"
+	FlagViqc  = "viqc"
+)
+
+var orderFlags = map[string]bool{FlagPost: true, FlagFcib: true, FlagFciq: true, FlagNompp: true, FlagViqc: true}
+
+// Time-in-force values supported by the exchange
+const (
+	TimeInForceGTC = "GTC"
+	TimeInForceIOC = "IOC"
+	TimeInForceGTD = "GTD"
+)
+
+// orderTimeRe matches scheduled time of an order: "0" for now, "+<n>" seconds from now or unix timestamp
+var orderTimeRe = regexp.MustCompile(`^\+?\d+$`)
+
+// clientOrderIdRe matches ids of orders accepted by the exchange as cl_ord_id:
+// long UUID, short UUID without hyphens or free text up to 18 characters
+var clientOrderIdRe = regexp.MustCompile(`^([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9a-fA-F]{32}|[\x21-\x7e]{1,18})$`)
+
+// orderRules describes which prices are used by an order type
+type orderRules struct {
+	price   bool
+	price2  bool
+	trigger bool
+	// trailing orders accept only relative prices
+	trailing bool
+}
+
+var orderTypes = map[string]orderRules{
+	OrderMarket:            {},
+	OrderLimit:             {price: true},
+	OrderStopLoss:          {price: true, trigger: true},
+	OrderStopLossLimit:     {price: true, price2: true, trigger: true},
+	OrderTakeProfit:        {price: true, trigger: true},
+	OrderTakeProfitLimit:   {price: true, price2: true, trigger: true},
+	OrderTrailingStop:      {price: true, trigger: true, trailing: true},
+	OrderTrailingStopLimit: {price: true, price2: true, trigger: true, trailing: true},
+	OrderSettlePosition:    {},
+}
+
+// OrderParams describes an order to place on the exchange
+type OrderParams struct {
+	Pair      string
+	Direction string
+	OrderType string
+	// Price is a limit price for limit orders and a trigger price for stop-loss/take-profit orders
+	Price decimal.Decimal
+	// Price2 is a limit price for *-limit orders
+	Price2 decimal.Decimal
+	// PriceOffset makes Price relative: "+" or "-" from the last traded price, "#" in the direction of the order
+	PriceOffset  string
+	PricePercent bool
+	// Price2Offset makes Price2 relative to the trigger price, the same way as PriceOffset
+	Price2Offset  string
+	Price2Percent bool
+	// Trigger is the price signal for triggered orders: "last" (default) or "index"
+	Trigger  string
+	Leverage string
+	Volume   decimal.Decimal
+	// OFlags are order flags: post, fcib, fciq, nompp, viqc
+	OFlags []string
+	// TimeInForce is GTC (default), IOC or GTD, GTD requires ExpireTm
+	TimeInForce string
+	// StartTm and ExpireTm are scheduled start and expiration: "0" for now, "+<n>" seconds from now or unix timestamp
+	StartTm  string
+	ExpireTm string
+	// Deadline is RFC3339 time after which the order should be rejected by the exchange
+	Deadline   string
+	ReduceOnly bool
+	// ValidateOnly asks the exchange to validate the order without placing it
+	ValidateOnly bool
+	// ClientOrderId is an id of the order set by the client: UUID or free text up to 18 characters
+	ClientOrderId string
+}
+
+// Validate checks that the order has all fields required by its type and no unsupported ones
+// Returns ErrInvalidOrder with a description of the problem
+func (p OrderParams) Validate() error {
+	rules, ok := orderTypes[p.OrderType]
+	if !ok {
+		return fmt.Errorf("%w: unknown order type %q", ErrInvalidOrder, p.OrderType)
+	}
+	if p.Pair == "" {
+		return fmt.Errorf("%w: pair is required", ErrInvalidOrder)
+	}
+	if p.Direction != "buy" && p.Direction != "sell" {
+		return fmt.Errorf("%w: direction should be buy or sell, got %q", ErrInvalidOrder, p.Direction)
+	}
+	if p.Volume.Sign() <= 0 {
+		return fmt.Errorf("%w: volume should be positive", ErrInvalidOrder)
+	}
+	if err := checkPrice("price", p.Price, p.PriceOffset, p.PricePercent, rules.price); err != nil {
+		return err
+	}
+	if err := checkPrice("price2", p.Price2, p.Price2Offset, p.Price2Percent, rules.price2); err != nil {
+		return err
+	}
+	if rules.trailing {
+		if p.PriceOffset != "+" {
+			return fmt.Errorf("%w: %s requires price as offset with \"+\"", ErrInvalidOrder, p.OrderType)
+		}
+		if rules.price2 && p.Price2Offset != "+" && p.Price2Offset != "-" {
+			return fmt.Errorf("%w: %s requires price2 as offset with \"+\" or \"-\"", ErrInvalidOrder, p.OrderType)
+		}
+	}
+	switch {
+	case p.Trigger == "":
+	case !rules.trigger:
+		return fmt.Errorf("%w: trigger is not supported by %s orders", ErrInvalidOrder, p.OrderType)
+	case p.Trigger != "last" && p.Trigger != "index":
+		return fmt.Errorf("%w: trigger should be last or index, got %q", ErrInvalidOrder, p.Trigger)
+	}
+	if p.OrderType == OrderSettlePosition && p.Leverage == "" {
+		return fmt.Errorf("%w: %s requires leverage", ErrInvalidOrder, p.OrderType)
+	}
+	if p.ClientOrderId != "" && !clientOrderIdRe.MatchString(p.ClientOrderId) {
+		return fmt.Errorf("%w: client order id should be UUID or up to 18 characters, got %q", ErrInvalidOrder, p.ClientOrderId)
+	}
+	return p.validateOptions(time.Now())
+}
+
+// validateOptions checks order flags and time-in-force options and their combinations
+func (p OrderParams) validateOptions(now time.Time) error {
+	flags := make(map[string]bool)
+	for _, f := range p.OFlags {
+		if !orderFlags[f] {
+			return fmt.Errorf("%w: unknown order flag %q", ErrInvalidOrder, f)
+		}
+		flags[f] = true
+	}
+	if flags[FlagFcib] && flags[FlagFciq] {
+		return fmt.Errorf("%w: flags fcib and fciq cannot be used together", ErrInvalidOrder)
+	}
+	if flags[FlagPost] && p.OrderType != OrderLimit {
+		return fmt.Errorf("%w: post-only flag is supported only by limit orders", ErrInvalidOrder)
+	}
+	if flags[FlagViqc] && p.Leverage != "" {
+		return fmt.Errorf("%w: volume in quote currency is not available for leveraged orders", ErrInvalidOrder)
+	}
+	switch p.TimeInForce {
+	case "", TimeInForceGTC, TimeInForceGTD:
+	case TimeInForceIOC:
+		if flags[FlagPost] {
+			return fmt.Errorf("%w: post-only order cannot be immediate-or-cancel", ErrInvalidOrder)
+		}
+	default:
+		return fmt.Errorf("%w: unknown time in force %q", ErrInvalidOrder, p.TimeInForce)
+	}
+	if (p.TimeInForce == TimeInForceGTD) != (p.ExpireTm != "") {
+		return fmt.Errorf("%w: expiration time should be set only for GTD orders", ErrInvalidOrder)
+	}
+	if p.StartTm != "" && !orderTimeRe.MatchString(p.StartTm) {
+		return fmt.Errorf("%w: wrong format of start time %q", ErrInvalidOrder, p.StartTm)
+	}
+	if p.ExpireTm != "" && !orderTimeRe.MatchString(p.ExpireTm) {
+		return fmt.Errorf("%w: wrong format of expiration time %q", ErrInvalidOrder, p.ExpireTm)
+	}
+	if p.Deadline != "" {
+		deadline, err := time.Parse(time.RFC3339, p.Deadline)
+		if err != nil {
+			return fmt.Errorf("%w: wrong format of deadline: %v", ErrInvalidOrder, err)
+		}
+		if !deadline.After(now) {
+			return fmt.Errorf("%w: deadline is in the past", ErrInvalidOrder)
+		}
+	}
+	return nil
+}
+
+// formatFlags joins order flags to comma separated list
+func formatFlags(flags []string) string {
+	return strings.Join(flags, ",")
+}
+
+// checkPrice checks a price of an order
+// Argument required tells if the order type uses the price
+func checkPrice(name string, price decimal.Decimal, offset string, percent, required bool) error {
+	if !required {
+		if !price.IsZero() || offset != "" || percent {
+			return fmt.Errorf("%w: %s is not used by this order type", ErrInvalidOrder, name)
+		}
+		return nil
+	}
+	if price.Sign() <= 0 {
+		return fmt.Errorf("%w: %s should be positive", ErrInvalidOrder, name)
+	}
+	switch offset {
+	case "", "+", "-", "#":
+	default:
+		return fmt.Errorf("%w: unknown offset %q of %s", ErrInvalidOrder, offset, name)
+	}
+	if percent && offset == "" {
+		return fmt.Errorf("%w: %s in percents should be an offset", ErrInvalidOrder, name)
+	}
+	return nil
+}
+
+// formatPrice formats the price for the exchange, returns empty string for zero price
+func formatPrice(price decimal.Decimal, offset string, percent bool) string {
+	if price.IsZero() {
+		return ""
+	}
+	s := offset + price.String()
+	if percent {
+		s += "%"
+	}
+	return s
+}
@@ -0,0 +1,136 @@
+package kraken
+
+import (
+	"bth-trader/internal/decimal"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOrderParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  OrderParams
+		wantErr bool
+	}{
+		{"limit", OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: OrderLimit, Price: decimal.MustParse("20000"), Volume: decimal.MustParse("0.1")}, false},
+		{"limit without price", OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: OrderLimit, Volume: decimal.MustParse("0.1")}, true},
+		{"market", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderMarket, Volume: decimal.MustParse("0.1")}, false},
+		{"market with price", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderMarket, Price: decimal.MustParse("20000"), Volume: decimal.MustParse("0.1")}, true},
+		{"unknown type", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: "iceberg", Volume: decimal.MustParse("0.1")}, true},
+		{"wrong direction", OrderParams{Pair: "XBT/EUR", Direction: "hold", OrderType: OrderMarket, Volume: decimal.MustParse("0.1")}, true},
+		{"no volume", OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: OrderMarket}, true},
+		{"no pair", OrderParams{Direction: "buy", OrderType: OrderMarket, Volume: decimal.MustParse("1")}, true},
+		{"stop-loss index", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderStopLoss, Price: decimal.MustParse("19000"), Trigger: "index", Volume: decimal.MustParse("0.1")}, false},
+		{"stop-loss wrong trigger", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderStopLoss, Price: decimal.MustParse("19000"), Trigger: "mark", Volume: decimal.MustParse("0.1")}, true},
+		{"limit with trigger", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderLimit, Price: decimal.MustParse("19000"), Trigger: "last", Volume: decimal.MustParse("0.1")}, true},
+		{"stop-loss-limit", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderStopLossLimit, Price: decimal.MustParse("19000"), Price2: decimal.MustParse("18900"), Volume: decimal.MustParse("0.1")}, false},
+		{"stop-loss-limit without price2", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderStopLossLimit, Price: decimal.MustParse("19000"), Volume: decimal.MustParse("0.1")}, true},
+		{"take-profit-limit relative", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderTakeProfitLimit, Price: decimal.MustParse("5"), PriceOffset: "#", PricePercent: true, Price2: decimal.MustParse("10"), Price2Offset: "-", Volume: decimal.MustParse("0.1")}, false},
+		{"percent without offset", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderTakeProfit, Price: decimal.MustParse("5"), PricePercent: true, Volume: decimal.MustParse("0.1")}, true},
+		{"trailing-stop", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderTrailingStop, Price: decimal.MustParse("2"), PriceOffset: "+", PricePercent: true, Volume: decimal.MustParse("0.1")}, false},
+		{"trailing-stop absolute", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderTrailingStop, Price: decimal.MustParse("19000"), Volume: decimal.MustParse("0.1")}, true},
+		{"settle-position", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderSettlePosition, Leverage: "2", Volume: decimal.MustParse("0.1")}, false},
+		{"settle-position without leverage", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderSettlePosition, Volume: decimal.MustParse("0.1")}, true},
+		{"client order id uuid", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderMarket, Volume: decimal.MustParse("0.1"), ClientOrderId: "6d1b345e-2821-40e2-ad83-4ecb18a06876"}, false},
+		{"client order id text", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderMarket, Volume: decimal.MustParse("0.1"), ClientOrderId: "grid-42"}, false},
+		{"client order id too long", OrderParams{Pair: "XBT/EUR", Direction: "sell", OrderType: OrderMarket, Volume: decimal.MustParse("0.1"), ClientOrderId: "a-very-long-client-order-id"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidOrder) {
+				t.Errorf("Validate() error = %v, expected ErrInvalidOrder", err)
+			}
+		})
+	}
+}
+
+func TestNewOrderMsg(t *testing.T) {
+	tests := []struct {
+		name   string
+		params OrderParams
+		want   AddOrderMsg
+	}{
+		{
+			name:   "market",
+			params: OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: OrderMarket, Volume: decimal.MustParse("0.5")},
+			want: AddOrderMsg{
+				Event: "addOrder", OrderType: "market", Pair: "XBT/EUR", Token: "token", Type: "buy", UserRef: "10", Volume: "0.5",
+			},
+		},
+		{
+			name: "trailing-stop-limit",
+			params: OrderParams{
+				Pair: "XBT/EUR", Direction: "sell", OrderType: OrderTrailingStopLimit,
+				Price: decimal.MustParse("1.5"), PriceOffset: "+", PricePercent: true, Price2: decimal.MustParse("20"), Price2Offset: "-", Trigger: "index", Volume: decimal.MustParse("0.5"),
+			},
+			want: AddOrderMsg{
+				Event: "addOrder", OrderType: "trailing-stop-limit", Pair: "XBT/EUR", Price: "+1.5%", Price2: "-20", Trigger: "index",
+				Token: "token", Type: "sell", UserRef: "10", Volume: "0.5",
+			},
+		},
+		{
+			name: "options",
+			params: OrderParams{
+				Pair: "XBT/EUR", Direction: "buy", OrderType: OrderLimit, Price: decimal.MustParse("20000"), Volume: decimal.MustParse("0.5"),
+				OFlags: []string{FlagPost, FlagFciq}, TimeInForce: TimeInForceGTD, ExpireTm: "+60", ReduceOnly: true, ValidateOnly: true,
+			},
+			want: AddOrderMsg{
+				Event: "addOrder", OrderType: "limit", Pair: "XBT/EUR", Price: "20000", OFlags: "post,fciq", TimeInForce: "GTD",
+				ExpireTm: "+60", ReduceOnly: true, Validate: "true", Token: "token", Type: "buy", UserRef: "10", Volume: "0.5",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewOrderMsg(10, tt.params, "token"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewOrderMsg() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderParams_validateOptions(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	limit := OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: OrderLimit, Price: decimal.MustParse("20000"), Volume: decimal.MustParse("0.1")}
+	market := OrderParams{Pair: "XBT/EUR", Direction: "buy", OrderType: OrderMarket, Volume: decimal.MustParse("0.1")}
+	with := func(p OrderParams, f func(p *OrderParams)) OrderParams {
+		f(&p)
+		return p
+	}
+	tests := []struct {
+		name    string
+		params  OrderParams
+		wantErr bool
+	}{
+		{"no options", limit, false},
+		{"post-only", with(limit, func(p *OrderParams) { p.OFlags = []string{FlagPost, FlagFciq} }), false},
+		{"post-only market", with(market, func(p *OrderParams) { p.OFlags = []string{FlagPost} }), true},
+		{"unknown flag", with(limit, func(p *OrderParams) { p.OFlags = []string{"hidden"} }), true},
+		{"fee in both currencies", with(limit, func(p *OrderParams) { p.OFlags = []string{FlagFcib, FlagFciq} }), true},
+		{"viqc with leverage", with(market, func(p *OrderParams) { p.OFlags = []string{FlagViqc}; p.Leverage = "2" }), true},
+		{"ioc", with(limit, func(p *OrderParams) { p.TimeInForce = TimeInForceIOC }), false},
+		{"ioc post-only", with(limit, func(p *OrderParams) { p.TimeInForce = TimeInForceIOC; p.OFlags = []string{FlagPost} }), true},
+		{"unknown time in force", with(limit, func(p *OrderParams) { p.TimeInForce = "FOK" }), true},
+		{"gtd", with(limit, func(p *OrderParams) { p.TimeInForce = TimeInForceGTD; p.ExpireTm = "+3600" }), false},
+		{"gtd without expiration", with(limit, func(p *OrderParams) { p.TimeInForce = TimeInForceGTD }), true},
+		{"expiration without gtd", with(limit, func(p *OrderParams) { p.ExpireTm = "1659355200" }), true},
+		{"scheduled start", with(limit, func(p *OrderParams) { p.StartTm = "+60" }), false},
+		{"wrong start", with(limit, func(p *OrderParams) { p.StartTm = "tomorrow" }), true},
+		{"deadline", with(limit, func(p *OrderParams) { p.Deadline = "2022-08-01T12:00:30Z" }), false},
+		{"deadline in past", with(limit, func(p *OrderParams) { p.Deadline = "2022-08-01T11:59:30Z" }), true},
+		{"wrong deadline", with(limit, func(p *OrderParams) { p.Deadline = "12:00:30" }), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.validateOptions(now); (err != nil) != tt.wantErr {
+				t.Errorf("validateOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package kraken
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RequestTimeout is time to wait for a reply to a request
+const RequestTimeout = time.Second * 10
+
+// requestTtl is time after which requests without reply are removed from the registry
+const requestTtl = time.Minute * 5
+
+// ErrTimeout is returned when the server did not reply in time
+var ErrTimeout = errors.New("no reply from the server")
+
+// ErrRejected is returned when the server replied with an error
+var ErrRejected = errors.New("request rejected by the server")
+
+// Reply is an answer from the server to a request with reqid, e.g. addOrderStatus or cancelOrderStatus
+type Reply struct {
+	Event  string
+	ReqId  int
+	Status string
+	TxId   string
+	Error  string
+	// OriginalTxId is id of the edited order in editOrderStatus
+	OriginalTxId string
+	// Count is number of canceled orders in cancelAllStatus
+	Count int
+	// RefId is userref of the order which the request was registered for
+	RefId int
+}
+
+// Err returns ErrRejected with the error message if the server rejected the request
+func (r Reply) Err() error {
+	if r.Status == "error" {
+		return fmt.Errorf("%w: %s", ErrRejected, r.Error)
+	}
+	return nil
+}
+
+type pendingRequest struct {
+	refId     int
+	replies   chan Reply
+	createdAt time.Time
+}
+
+// Requests is a registry of requests waiting for replies from the server
+// Each request gets unique reqid, the reply with the same reqid is routed back to the request.
+type Requests struct {
+	m       *sync.Mutex
+	seq     int
+	pending map[int]*pendingRequest
+}
+
+// NewRequests creates an empty registry
+func NewRequests() *Requests {
+	return &Requests{
+		m:       &sync.Mutex{},
+		pending: make(map[int]*pendingRequest),
+	}
+}
+
+// next returns new unique reqid without registering a request
+func (r *Requests) next() int {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.seq++
+	return r.seq
+}
+
+// Register adds a new request to the registry
+// Argument refId is userref of the order related to the request, 0 if there is no such order.
+// Returns unique reqid for the request and a channel which receives the reply.
+// Requests without reply are removed after requestTtl.
+func (r *Requests) Register(refId int) (int, <-chan Reply) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	now := time.Now()
+	for reqId, p := range r.pending {
+		if now.Sub(p.createdAt) > requestTtl {
+			delete(r.pending, reqId)
+		}
+	}
+	r.seq++
+	p := &pendingRequest{refId: refId, replies: make(chan Reply, 1), createdAt: now}
+	r.pending[r.seq] = p
+	return r.seq, p.replies
+}
+
+// Forget removes the request from the registry, its reply will be ignored
+func (r *Requests) Forget(reqId int) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	delete(r.pending, reqId)
+}
+
+// Resolve routes the reply to the request with the same reqid
+// Fills RefId of the reply from the registered request.
+// Returns false if there is no request waiting for the reply.
+func (r *Requests) Resolve(reply *Reply) bool {
+	r.m.Lock()
+	p, ok := r.pending[reply.ReqId]
+	delete(r.pending, reply.ReqId)
+	r.m.Unlock()
+	if !ok {
+		return false
+	}
+	reply.RefId = p.refId
+	p.replies <- *reply
+	return true
+}
+
+// Wait blocks until the reply is received, timeout is reached or the context is done
+// Returns ErrTimeout if the reply was not received, error of the context if it is done first
+// and ErrRejected if the server replied with an error
+func Wait(ctx context.Context, replies <-chan Reply, timeout time.Duration) (Reply, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case reply := <-replies:
+		return reply, reply.Err()
+	case <-ctx.Done():
+		return Reply{}, ctx.Err()
+	case <-timer.C:
+		return Reply{}, ErrTimeout
+	}
+}
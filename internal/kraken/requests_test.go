@@ -0,0 +1,79 @@
+package kraken
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRequests_Resolve(t *testing.T) {
+	r := NewRequests()
+	first, firstReplies := r.Register(101)
+	second, _ := r.Register(0)
+	if first == second {
+		t.Fatalf("Register() returned the same reqid %d twice", first)
+	}
+	tests := []struct {
+		name   string
+		reply  Reply
+		want   bool
+		wantId int
+	}{
+		{"registered", Reply{ReqId: first, Status: "ok", TxId: "ABC"}, true, 101},
+		{"already resolved", Reply{ReqId: first, Status: "ok"}, false, 0},
+		{"unknown", Reply{ReqId: 777, Status: "ok"}, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Resolve(&tt.reply); got != tt.want {
+				t.Errorf("Resolve() = %v, want %v", got, tt.want)
+			}
+			if tt.reply.RefId != tt.wantId {
+				t.Errorf("Resolve() set RefId %d, want %d", tt.reply.RefId, tt.wantId)
+			}
+		})
+	}
+	want := Reply{ReqId: first, Status: "ok", TxId: "ABC", RefId: 101}
+	if got := <-firstReplies; !reflect.DeepEqual(got, want) {
+		t.Errorf("received reply %v, want %v", got, want)
+	}
+	r.Forget(second)
+	if got := r.Resolve(&Reply{ReqId: second}); got {
+		t.Errorf("Resolve() for forgotten request = %v, want false", got)
+	}
+}
+
+func TestWait(t *testing.T) {
+	tests := []struct {
+		name     string
+		replies  []Reply
+		canceled bool
+		wantErr  error
+	}{
+		{"ok", []Reply{{Status: "ok"}}, false, nil},
+		{"rejected", []Reply{{Status: "error", Error: "EOrder:Unknown order"}}, false, ErrRejected},
+		{"timeout", nil, false, ErrTimeout},
+		{"canceled", nil, true, context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := make(chan Reply, 1)
+			for _, r := range tt.replies {
+				ch <- r
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			timeout := time.Millisecond * 10
+			if tt.canceled {
+				cancel()
+				// the test would hang if the canceled context is ignored
+				timeout = time.Minute
+			}
+			if _, err := Wait(ctx, ch, timeout); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Wait() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -11,8 +11,8 @@ import (
 	"log"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -24,6 +24,8 @@ type RestClient struct {
 	decodedKey []byte
 	baseUrl    string
 	httpClient *http.Client
+	// lastNonce is the last nonce sent, nonce must increase with every request
+	lastNonce atomic.Int64
 }
 
 func NewRestClient(apiKey, privateKey string) *RestClient {
@@ -52,8 +54,7 @@ type WsAuthToken struct {
 
 func (r *RestClient) WsToken() (*WsAuthToken, error) {
 	payload := make(url.Values)
-	nonce := time.Now().UnixMilli()
-	payload.Set("nonce", fmt.Sprintf("%d", nonce))
+	payload.Set("nonce", fmt.Sprintf("%d", r.nonce()))
 	resp, err := r.post("/0/private/GetWebSocketsToken", payload)
 	if err != nil {
 		return nil, fmt.Errorf("cannot request auth token for WS: %v", err)
@@ -72,43 +73,6 @@ func (r *RestClient) WsToken() (*WsAuthToken, error) {
 	return &data.Result, nil
 }
 
-type balanceResponse struct {
-	Result map[string]string `json:"result"`
-	Error  []string          `json:"error"`
-}
-
-type Balances map[string]float64
-
-func (r *RestClient) Balances() (Balances, error) {
-	payload := make(url.Values)
-	nonce := time.Now().UnixMilli()
-	payload.Set("nonce", fmt.Sprintf("%d", nonce))
-	resp, err := r.post("/0/private/Balance", payload)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get balances: %w", err)
-	}
-	defer func(Body io.ReadCloser) {
-		_ = Body.Close()
-	}(resp.Body)
-	reader := json.NewDecoder(resp.Body)
-	var respData balanceResponse
-	if err = reader.Decode(&respData); err != nil {
-		return nil, fmt.Errorf("cannot decode response: %w", err)
-	}
-	if len(respData.Error) > 0 {
-		return nil, fmt.Errorf("remote server returned an error: %v", respData.Error)
-	}
-	log.Printf("balance response: %#v", respData.Result)
-	balances := make(Balances)
-	for c, v := range respData.Result {
-		balances[c], err = strconv.ParseFloat(v, 64)
-		if err != nil {
-			return nil, fmt.Errorf("cannot parse balance volume: %w; response: %v", err, respData)
-		}
-	}
-	return balances, nil
-}
-
 type OrderResp struct {
 	Description struct {
 		Order string `json:"order"`
@@ -117,6 +81,20 @@ type OrderResp struct {
 	TxId []string `json:"txid"`
 }
 
+// nonce returns current time in milliseconds, but always greater than the previous nonce
+func (r *RestClient) nonce() int64 {
+	for {
+		last := r.lastNonce.Load()
+		next := time.Now().UnixMilli()
+		if next <= last {
+			next = last + 1
+		}
+		if r.lastNonce.CompareAndSwap(last, next) {
+			return next
+		}
+	}
+}
+
 func (r *RestClient) post(uri string, data url.Values) (*http.Response, error) {
 	fullUrl := r.baseUrl + uri
 	req, err := http.NewRequest("POST", fullUrl, strings.NewReader(data.Encode()))
@@ -0,0 +1,111 @@
+package kraken
+
+import (
+	"bth-trader/internal/decimal"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Statuses of asset pairs, orders are accepted without limitations only for online pairs
+const (
+	PairOnline     = "online"
+	PairCancelOnly = "cancel_only"
+	PairPostOnly   = "post_only"
+	PairLimitOnly  = "limit_only"
+	PairReduceOnly = "reduce_only"
+)
+
+// AssetPair is information about a tradable pair returned by REST API
+type AssetPair struct {
+	// Name is the key of the pair in REST API, e.g. XXBTZEUR
+	Name string `json:"-"`
+	// AltName is the alternative name, e.g. XBTEUR, REST API returns pairs of orders with this name
+	AltName string `json:"altname"`
+	// WsName is the name used by websocket API, e.g. XBT/EUR
+	WsName string `json:"wsname"`
+	Base   string `json:"base"`
+	Quote  string `json:"quote"`
+	// PairDecimals is number of decimals of prices
+	PairDecimals int `json:"pair_decimals"`
+	CostDecimals int `json:"cost_decimals"`
+	// LotDecimals is number of decimals of volumes
+	LotDecimals int `json:"lot_decimals"`
+	// TickSize is the minimal change of prices
+	TickSize decimal.Decimal `json:"tick_size"`
+	// OrderMin is the minimal volume of orders in the base currency
+	OrderMin decimal.Decimal `json:"ordermin"`
+	// CostMin is the minimal cost of orders in the quote currency
+	CostMin decimal.Decimal `json:"costmin"`
+	Status  string          `json:"status"`
+}
+
+// Asset is information about an asset returned by REST API
+type Asset struct {
+	// Name is the key of the asset in REST API, e.g. XXBT
+	Name string `json:"-"`
+	// AltName is the alternative name, e.g. XBT, websocket API uses this name in pairs
+	AltName         string `json:"altname"`
+	Decimals        int    `json:"decimals"`
+	DisplayDecimals int    `json:"display_decimals"`
+	Status          string `json:"status"`
+}
+
+type assetPairsResponse struct {
+	Result map[string]*AssetPair `json:"result"`
+	Error  []string              `json:"error"`
+}
+
+// AssetPairs returns all tradable pairs by their names
+func (r *RestClient) AssetPairs() (map[string]*AssetPair, error) {
+	var data assetPairsResponse
+	if err := r.public("/0/public/AssetPairs", &data); err != nil {
+		return nil, fmt.Errorf("cannot get asset pairs: %w", err)
+	}
+	if len(data.Error) > 0 {
+		return nil, fmt.Errorf("remote server returned an error: %v", data.Error)
+	}
+	for name, pair := range data.Result {
+		pair.Name = name
+	}
+	return data.Result, nil
+}
+
+type assetsResponse struct {
+	Result map[string]*Asset `json:"result"`
+	Error  []string          `json:"error"`
+}
+
+// Assets returns all assets by their names
+func (r *RestClient) Assets() (map[string]*Asset, error) {
+	var data assetsResponse
+	if err := r.public("/0/public/Assets", &data); err != nil {
+		return nil, fmt.Errorf("cannot get assets: %w", err)
+	}
+	if len(data.Error) > 0 {
+		return nil, fmt.Errorf("remote server returned an error: %v", data.Error)
+	}
+	for name, asset := range data.Result {
+		asset.Name = name
+	}
+	return data.Result, nil
+}
+
+// public sends a request to a public endpoint and decodes the response to the result
+func (r *RestClient) public(uri string, result any) error {
+	resp, err := r.httpClient.Get(r.baseUrl + uri)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("cannot decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package kraken
+
+import (
+	"bth-trader/internal/decimal"
+	"fmt"
+	"net/url"
+)
+
+// Balances are volumes of assets on the account by names of assets, e.g. XXBT or ZEUR
+type Balances map[string]decimal.Decimal
+
+type balanceResponse struct {
+	Result Balances `json:"result"`
+	Error  []string `json:"error"`
+}
+
+// Balances returns balances of all assets of the account
+func (r *RestClient) Balances() (Balances, error) {
+	var data balanceResponse
+	if err := r.private("/0/private/Balance", make(url.Values), &data); err != nil {
+		return nil, fmt.Errorf("cannot get balances: %w", err)
+	}
+	if len(data.Error) > 0 {
+		return nil, fmt.Errorf("remote server returned an error: %v", data.Error)
+	}
+	if data.Result == nil {
+		data.Result = make(Balances)
+	}
+	return data.Result, nil
+}
+
+// TradeBalance is a summary of collateral balances and margin positions, values are in the asset of the request
+type TradeBalance struct {
+	// EquivalentBalance is combined balance of all currencies
+	EquivalentBalance decimal.Decimal `json:"eb"`
+	// TradeBalance is combined balance of all equity currencies
+	TradeBalance decimal.Decimal `json:"tb"`
+	// Margin is margin amount of open positions
+	Margin decimal.Decimal `json:"m"`
+	// UnrealizedPnL is unrealized net profit/loss of open positions
+	UnrealizedPnL decimal.Decimal `json:"n"`
+	// CostBasis is cost basis of open positions
+	CostBasis decimal.Decimal `json:"c"`
+	// Valuation is current floating valuation of open positions
+	Valuation decimal.Decimal `json:"v"`
+	// Equity is trade balance plus unrealized net profit/loss
+	Equity decimal.Decimal `json:"e"`
+	// FreeMargin is equity minus initial margin, maximum margin available to open new positions
+	FreeMargin decimal.Decimal `json:"mf"`
+	// MarginLevel is equity divided by initial margin in percents, zero without open positions
+	MarginLevel decimal.Decimal `json:"ml"`
+	// Unexecuted is value of unfilled and partially filled orders
+	Unexecuted decimal.Decimal `json:"uv"`
+}
+
+type tradeBalanceResponse struct {
+	Result TradeBalance `json:"result"`
+	Error  []string     `json:"error"`
+}
+
+// TradeBalance returns the summary of the account in the asset, e.g. ZEUR, kraken uses ZUSD if the asset is empty
+func (r *RestClient) TradeBalance(asset string) (*TradeBalance, error) {
+	payload := make(url.Values)
+	if asset != "" {
+		payload.Set("asset", asset)
+	}
+	var data tradeBalanceResponse
+	if err := r.private("/0/private/TradeBalance", payload, &data); err != nil {
+		return nil, fmt.Errorf("cannot get trade balance: %w", err)
+	}
+	if len(data.Error) > 0 {
+		return nil, fmt.Errorf("remote server returned an error: %v", data.Error)
+	}
+	return &data.Result, nil
+}
@@ -0,0 +1,213 @@
+package kraken
+
+import (
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/entities"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// queryOrdersLimit is maximum number of orders in a single QueryOrders request
+const queryOrdersLimit = 50
+
+// OrderInfo is information about an order returned by REST API
+type OrderInfo struct {
+	UserRef int     `json:"userref"`
+	ClOrdId string  `json:"cl_ord_id"`
+	Status  string  `json:"status"`
+	OpenTm  float64 `json:"opentm"`
+	CloseTm float64 `json:"closetm"`
+	Descr   struct {
+		Pair      string `json:"pair"`
+		Type      string `json:"type"`
+		OrderType string `json:"ordertype"`
+		Price     string `json:"price"`
+		Price2    string `json:"price2"`
+	} `json:"descr"`
+	Vol        string `json:"vol"`
+	VolExec    string `json:"vol_exec"`
+	Cost       string `json:"cost"`
+	Fee        string `json:"fee"`
+	AvgPrice   string `json:"price"`
+	StopPrice  string `json:"stopprice"`
+	LimitPrice string `json:"limitprice"`
+	Misc       string `json:"misc"`
+	OFlags     string `json:"oflags"`
+	Reason     string `json:"reason"`
+}
+
+// Order converts information about the order to the order entity
+func (i *OrderInfo) Order(orderId string) (*entities.Order, error) {
+	order := &entities.Order{
+		OrderId:       orderId,
+		RefId:         i.UserRef,
+		ClientOrderId: i.ClOrdId,
+		Pair:          i.Descr.Pair,
+		Side:          i.Descr.Type,
+		OrderType:     i.Descr.OrderType,
+		Status:        i.Status,
+		OpenTime:      unixTime(i.OpenTm),
+		CloseTime:     unixTime(i.CloseTm),
+		CancelReason:  i.Reason,
+		Misc:          i.Misc,
+		OFlags:        i.OFlags,
+	}
+	for _, f := range []struct {
+		name string
+		src  string
+		dst  *decimal.Decimal
+	}{
+		{"price", i.Descr.Price, &order.Price},
+		{"price2", i.Descr.Price2, &order.Price2},
+		{"vol", i.Vol, &order.Volume},
+		{"vol_exec", i.VolExec, &order.VolumeExec},
+		{"cost", i.Cost, &order.Cost},
+		{"fee", i.Fee, &order.Fee},
+		{"avg price", i.AvgPrice, &order.AvgPrice},
+		{"stopprice", i.StopPrice, &order.StopPrice},
+		{"limitprice", i.LimitPrice, &order.LimitPrice},
+	} {
+		if f.src == "" {
+			continue
+		}
+		v, err := decimal.Parse(f.src)
+		if err != nil {
+			return nil, fmt.Errorf("wrong %s of order %s: %w", f.name, orderId, err)
+		}
+		*f.dst = v
+	}
+	return order, nil
+}
+
+func unixTime(ts float64) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	sec, frac := math.Modf(ts)
+	return time.Unix(int64(sec), int64(math.Round(frac*1e6))*1e3)
+}
+
+type openOrdersResponse struct {
+	Result struct {
+		Open map[string]*OrderInfo `json:"open"`
+	} `json:"result"`
+	Error []string `json:"error"`
+}
+
+// OpenOrders returns all open orders of the account
+func (r *RestClient) OpenOrders() ([]*entities.Order, error) {
+	var data openOrdersResponse
+	if err := r.private("/0/private/OpenOrders", make(url.Values), &data); err != nil {
+		return nil, fmt.Errorf("cannot get open orders: %w", err)
+	}
+	if len(data.Error) > 0 {
+		return nil, fmt.Errorf("remote server returned an error: %v", data.Error)
+	}
+	return toOrders(data.Result.Open)
+}
+
+type closedOrdersResponse struct {
+	Result struct {
+		Closed map[string]*OrderInfo `json:"closed"`
+		Count  int                   `json:"count"`
+	} `json:"result"`
+	Error []string `json:"error"`
+}
+
+// ClosedOrders returns orders of the account closed after the start time
+// Kraken returns closed orders by pages, all pages are requested one by one.
+func (r *RestClient) ClosedOrders(start time.Time) ([]*entities.Order, error) {
+	var result []*entities.Order
+	for {
+		payload := make(url.Values)
+		payload.Set("start", strconv.FormatInt(start.Unix(), 10))
+		payload.Set("closetime", "close")
+		payload.Set("ofs", strconv.Itoa(len(result)))
+		var data closedOrdersResponse
+		if err := r.private("/0/private/ClosedOrders", payload, &data); err != nil {
+			return nil, fmt.Errorf("cannot get closed orders: %w", err)
+		}
+		if len(data.Error) > 0 {
+			return nil, fmt.Errorf("remote server returned an error: %v", data.Error)
+		}
+		page, err := toOrders(data.Result.Closed)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, page...)
+		if len(page) == 0 || len(result) >= data.Result.Count {
+			return result, nil
+		}
+	}
+}
+
+type queryOrdersResponse struct {
+	Result map[string]*OrderInfo `json:"result"`
+	Error  []string              `json:"error"`
+}
+
+// QueryOrders returns information about orders with given ids
+func (r *RestClient) QueryOrders(orderIds ...string) ([]*entities.Order, error) {
+	var result []*entities.Order
+	for len(orderIds) > 0 {
+		batch := orderIds
+		if len(batch) > queryOrdersLimit {
+			batch = batch[:queryOrdersLimit]
+		}
+		orderIds = orderIds[len(batch):]
+		payload := make(url.Values)
+		payload.Set("txid", strings.Join(batch, ","))
+		var data queryOrdersResponse
+		if err := r.private("/0/private/QueryOrders", payload, &data); err != nil {
+			return nil, fmt.Errorf("cannot query orders: %w", err)
+		}
+		if len(data.Error) > 0 {
+			return nil, fmt.Errorf("remote server returned an error: %v", data.Error)
+		}
+		found, err := toOrders(data.Result)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, found...)
+	}
+	return result, nil
+}
+
+// toOrders converts orders from REST API response to the entities sorted by their ids
+func toOrders(infos map[string]*OrderInfo) ([]*entities.Order, error) {
+	result := make([]*entities.Order, 0, len(infos))
+	for orderId, info := range infos {
+		order, err := info.Order(orderId)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, order)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].OrderId < result[j].OrderId
+	})
+	return result, nil
+}
+
+// private sends a request to a private endpoint and decodes the response to the result
+func (r *RestClient) private(uri string, payload url.Values, result any) error {
+	payload.Set("nonce", fmt.Sprintf("%d", r.nonce()))
+	resp, err := r.post(uri, payload)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("cannot decode response: %w", err)
+	}
+	return nil
+}
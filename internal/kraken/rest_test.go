@@ -1,10 +1,16 @@
 package kraken
 
 import (
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/entities"
 	"encoding/base64"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"reflect"
+	"strconv"
 	"testing"
+	"time"
 )
 
 func TestRestClient_sign(t *testing.T) {
@@ -58,3 +64,138 @@ func TestRestClient_sign(t *testing.T) {
 		})
 	}
 }
+
+func TestRestClient_OpenOrders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/0/private/OpenOrders" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"error":[],"result":{"open":{"OQCLML-BW3P3-BUCMWZ":{"refid":null,"userref":120,"status":"open","opentm":1688666559.8974,"starttm":0,"expiretm":0,"descr":{"pair":"XBTUSD","type":"buy","ordertype":"limit","price":"30010.0","price2":"0","leverage":"none","order":"buy 1.25000000 XBTUSD @ limit 30010.0","close":""},"vol":"1.25000000","vol_exec":"0.37500000","cost":"11253.7","fee":"0.00000","price":"30010.0","stopprice":"0.00000","limitprice":"0.00000","misc":"","oflags":"fciq"}}}}`))
+	}))
+	defer srv.Close()
+	r := NewRestClient("key", "")
+	r.baseUrl = srv.URL
+	got, err := r.OpenOrders()
+	if err != nil {
+		t.Fatalf("OpenOrders() error = %v", err)
+	}
+	want := []*entities.Order{{
+		OrderId: "OQCLML-BW3P3-BUCMWZ", RefId: 120, Pair: "XBTUSD", Side: "buy", OrderType: "limit", Status: "open",
+		Volume: decimal.MustParse("1.25"), VolumeExec: decimal.MustParse("0.375"), Cost: decimal.MustParse("11253.7"), AvgPrice: decimal.MustParse("30010"), Price: decimal.MustParse("30010"),
+		OpenTime: time.Unix(1688666559, 897400000), OFlags: "fciq",
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("OpenOrders() got %v, want %v", got[0], want[0])
+	}
+}
+
+func TestRestClient_ClosedOrders(t *testing.T) {
+	pages := []string{
+		`{"error":[],"result":{"closed":{"O1":{"userref":1,"status":"closed"},"O2":{"userref":2,"status":"canceled"}},"count":3}}`,
+		`{"error":[],"result":{"closed":{"O3":{"userref":3,"status":"expired"}},"count":3}}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		ofs, _ := strconv.Atoi(r.Form.Get("ofs"))
+		page := 0
+		if ofs > 0 {
+			page = 1
+		}
+		_, _ = w.Write([]byte(pages[page]))
+	}))
+	defer srv.Close()
+	r := NewRestClient("key", "")
+	r.baseUrl = srv.URL
+	got, err := r.ClosedOrders(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("ClosedOrders() error = %v", err)
+	}
+	if len(got) != 3 || got[0].OrderId != "O1" || got[2].Status != "expired" {
+		t.Errorf("ClosedOrders() got %v", got)
+	}
+}
+
+func TestRestClient_AssetPairs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/0/public/AssetPairs" || r.Method != http.MethodGet {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"error":[],"result":{"XXBTZEUR":{"altname":"XBTEUR","wsname":"XBT/EUR","aclass_base":"currency","base":"XXBT","aclass_quote":"currency","quote":"ZEUR","lot":"unit","cost_decimals":5,"pair_decimals":1,"lot_decimals":8,"lot_multiplier":1,"fee_volume_currency":"ZUSD","margin_call":80,"margin_stop":40,"ordermin":"0.0001","costmin":"0.45","tick_size":"0.1","status":"online"}}}`))
+	}))
+	defer srv.Close()
+	r := NewRestClient("key", "")
+	r.baseUrl = srv.URL
+	got, err := r.AssetPairs()
+	if err != nil {
+		t.Fatalf("AssetPairs() error = %v", err)
+	}
+	want := map[string]*AssetPair{"XXBTZEUR": {
+		Name: "XXBTZEUR", AltName: "XBTEUR", WsName: "XBT/EUR", Base: "XXBT", Quote: "ZEUR",
+		PairDecimals: 1, CostDecimals: 5, LotDecimals: 8, TickSize: decimal.MustParse("0.1"), OrderMin: decimal.MustParse("0.0001"), CostMin: decimal.MustParse("0.45"), Status: PairOnline,
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AssetPairs() got %+v, want %+v", got["XXBTZEUR"], want["XXBTZEUR"])
+	}
+}
+
+func TestRestClient_Assets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"error":[],"result":{"XXBT":{"aclass":"currency","altname":"XBT","decimals":10,"display_decimals":5,"status":"enabled"}}}`))
+	}))
+	defer srv.Close()
+	r := NewRestClient("key", "")
+	r.baseUrl = srv.URL
+	got, err := r.Assets()
+	if err != nil {
+		t.Fatalf("Assets() error = %v", err)
+	}
+	want := map[string]*Asset{"XXBT": {Name: "XXBT", AltName: "XBT", Decimals: 10, DisplayDecimals: 5, Status: "enabled"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Assets() got %+v, want %+v", got["XXBT"], want["XXBT"])
+	}
+}
+
+func TestRestClient_Balances(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/0/private/Balance" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"error":[],"result":{"ZEUR":"504.8610","XXBT":"0.0123456789","XETH":"0.0000000000"}}`))
+	}))
+	defer srv.Close()
+	r := NewRestClient("key", "")
+	r.baseUrl = srv.URL
+	got, err := r.Balances()
+	if err != nil {
+		t.Fatalf("Balances() error = %v", err)
+	}
+	want := Balances{"ZEUR": decimal.MustParse("504.861"), "XXBT": decimal.MustParse("0.0123456789"), "XETH": decimal.Zero}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Balances() got %v, want %v", got, want)
+	}
+}
+
+func TestRestClient_TradeBalance(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.URL.Path != "/0/private/TradeBalance" || r.Form.Get("asset") != "ZEUR" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, r.Form)
+		}
+		_, _ = w.Write([]byte(`{"error":[],"result":{"eb":"1101.3425","tb":"392.2264","m":"7.0354","n":"-10.0232","c":"21.1063","v":"31.1297","e":"382.2032","mf":"375.1678","ml":"5432.57","uv":"0.0000"}}`))
+	}))
+	defer srv.Close()
+	r := NewRestClient("key", "")
+	r.baseUrl = srv.URL
+	got, err := r.TradeBalance("ZEUR")
+	if err != nil {
+		t.Fatalf("TradeBalance() error = %v", err)
+	}
+	want := &TradeBalance{
+		EquivalentBalance: decimal.MustParse("1101.3425"), TradeBalance: decimal.MustParse("392.2264"), Margin: decimal.MustParse("7.0354"),
+		UnrealizedPnL: decimal.MustParse("-10.0232"), CostBasis: decimal.MustParse("21.1063"), Valuation: decimal.MustParse("31.1297"),
+		Equity: decimal.MustParse("382.2032"), FreeMargin: decimal.MustParse("375.1678"), MarginLevel: decimal.MustParse("5432.57"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TradeBalance() got %+v, want %+v", got, want)
+	}
+}
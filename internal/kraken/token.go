@@ -0,0 +1,120 @@
+package kraken
+
+import (
+	"github.com/ltunc/go-observer/observer"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// invalidTokenError is an error message the server responds with when the auth token is expired or invalid
+const invalidTokenError = "EAPI:Invalid token"
+
+// retryDelay is time to wait before next attempt if the token cannot be renewed
+const retryDelay = time.Second * 5
+
+// IsInvalidToken checks if the error message from the server is about invalid auth token
+func IsInvalidToken(errMsg string) bool {
+	return strings.Contains(errMsg, invalidTokenError)
+}
+
+// tokenSource requests new tokens from the exchange, implemented by RestClient
+type tokenSource interface {
+	WsToken() (*WsAuthToken, error)
+}
+
+// TokenManager keeps auth token for websocket requests fresh
+// Renews the token before it expires or when Refresh was requested.
+type TokenManager struct {
+	source     tokenSource
+	m          *sync.RWMutex
+	token      *WsAuthToken
+	receivedAt time.Time
+	refresh    chan struct{}
+	// Renewed notifies observers about every new token
+	Renewed *observer.Subject[string]
+}
+
+// NewTokenManager creates a manager, the first token should be received by calling Renew
+func NewTokenManager(source tokenSource) *TokenManager {
+	return &TokenManager{
+		source:  source,
+		m:       &sync.RWMutex{},
+		refresh: make(chan struct{}, 1),
+		Renewed: &observer.Subject[string]{},
+	}
+}
+
+// Token returns current token, empty string if there is no token yet
+func (t *TokenManager) Token() string {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	if t.token == nil {
+		return ""
+	}
+	return t.token.Token
+}
+
+// Renew requests new token from the exchange and replaces current one
+// Notifies observers about the new token
+func (t *TokenManager) Renew() error {
+	token, err := t.source.WsToken()
+	if err != nil {
+		return err
+	}
+	t.m.Lock()
+	t.token = token
+	t.receivedAt = time.Now()
+	t.m.Unlock()
+	t.Renewed.Fire(token.Token)
+	return nil
+}
+
+// Refresh asks the manager to renew the token as soon as possible
+// Does not block, the token is renewed by Run
+func (t *TokenManager) Refresh() {
+	select {
+	case t.refresh <- struct{}{}:
+	default:
+		// a refresh is already requested
+	}
+}
+
+// renewIn calculates time left until the token should be renewed
+// The token is renewed when 80% of its lifetime has passed
+func (t *TokenManager) renewIn(now time.Time) time.Duration {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	if t.token == nil {
+		return 0
+	}
+	lifetime := time.Duration(t.token.Expires) * time.Second
+	return t.receivedAt.Add(lifetime * 4 / 5).Sub(now)
+}
+
+// Run renews the token before it expires and when refresh is requested
+// Blocks the goroutine forever
+func (t *TokenManager) Run() {
+	timer := time.NewTimer(t.renewIn(time.Now()))
+	for {
+		select {
+		case <-timer.C:
+		case <-t.refresh:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+		next := retryDelay
+		if err := t.Renew(); err != nil {
+			log.Printf("cannot renew websocket auth token: %v", err)
+		} else {
+			log.Printf("websocket auth token renewed")
+			next = t.renewIn(time.Now())
+		}
+		timer.Reset(next)
+	}
+}
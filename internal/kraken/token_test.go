@@ -0,0 +1,92 @@
+package kraken
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type mockTokenSource struct {
+	tokens []*WsAuthToken
+	err    error
+}
+
+func (m *mockTokenSource) WsToken() (*WsAuthToken, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	token := m.tokens[0]
+	m.tokens = m.tokens[1:]
+	return token, nil
+}
+
+type tokenRecorder struct {
+	tokens []string
+}
+
+func (r *tokenRecorder) Notify(token string) {
+	r.tokens = append(r.tokens, token)
+}
+
+func TestTokenManager_Renew(t *testing.T) {
+	source := &mockTokenSource{tokens: []*WsAuthToken{{"first", 900}, {"second", 900}}}
+	tm := NewTokenManager(source)
+	rec := &tokenRecorder{}
+	tm.Renewed.Subscribe(rec)
+	if got := tm.Token(); got != "" {
+		t.Errorf("Token() before renew = %v, want empty", got)
+	}
+	for _, want := range []string{"first", "second"} {
+		if err := tm.Renew(); err != nil {
+			t.Fatalf("Renew() error: %v", err)
+		}
+		if got := tm.Token(); got != want {
+			t.Errorf("Token() = %v, want %v", got, want)
+		}
+	}
+	source.err = errors.New("test error")
+	if err := tm.Renew(); err == nil {
+		t.Errorf("Renew() expected error")
+	}
+	if got := tm.Token(); got != "second" {
+		t.Errorf("Token() after failed renew = %v, want second", got)
+	}
+	if want := []string{"first", "second"}; !reflect.DeepEqual(rec.tokens, want) {
+		t.Errorf("Renewed got notifications %v, want %v", rec.tokens, want)
+	}
+}
+
+func TestTokenManager_renewIn(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		token      *WsAuthToken
+		receivedAt time.Time
+		want       time.Duration
+	}{
+		{"no token", nil, time.Time{}, 0},
+		{"fresh", &WsAuthToken{"t", 900}, now, time.Second * 720},
+		{"old", &WsAuthToken{"t", 900}, now.Add(-time.Second * 700), time.Second * 20},
+		{"expired", &WsAuthToken{"t", 900}, now.Add(-time.Second * 1000), -time.Second * 280},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := NewTokenManager(&mockTokenSource{})
+			tm.token = tt.token
+			tm.receivedAt = tt.receivedAt
+			if got := tm.renewIn(now); got != tt.want {
+				t.Errorf("renewIn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsInvalidToken(t *testing.T) {
+	if !IsInvalidToken("EAPI:Invalid token") {
+		t.Errorf("IsInvalidToken() expected true")
+	}
+	if IsInvalidToken("EOrder:Insufficient funds") {
+		t.Errorf("IsInvalidToken() expected false")
+	}
+}
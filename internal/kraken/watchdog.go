@@ -0,0 +1,97 @@
+package kraken
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"time"
+)
+
+type pingMsg struct {
+	Event string `json:"event"`
+	ReqId int    `json:"reqid,omitempty"`
+}
+
+// seen records time of the received message and measures latency if the message is a reply for ping
+func (w *WsClient) seen(msg []byte) {
+	now := time.Now()
+	w.lastSeen.Store(now.UnixNano())
+	if !bytes.Contains(msg, []byte(`"pong"`)) {
+		return
+	}
+	var pong pingMsg
+	if err := json.Unmarshal(msg, &pong); err != nil || pong.Event != "pong" {
+		return
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	if sent, ok := w.pingSent[pong.ReqId]; ok {
+		w.latency.Store(int64(now.Sub(sent)))
+		delete(w.pingSent, pong.ReqId)
+	}
+}
+
+// LastSeen returns time when the last message from the server was received
+func (w *WsClient) LastSeen() time.Time {
+	return time.Unix(0, w.lastSeen.Load())
+}
+
+// Latency returns round-trip time of the last answered ping
+func (w *WsClient) Latency() time.Duration {
+	return time.Duration(w.latency.Load())
+}
+
+// Ping sends "ping" event to the server, the server should answer with "pong" event
+func (w *WsClient) Ping() error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.conn == nil {
+		return ErrNotConnected
+	}
+	reqId := w.requests.next()
+	// forget pings which were never answered
+	for id := range w.pingSent {
+		delete(w.pingSent, id)
+	}
+	w.pingSent[reqId] = time.Now()
+	return w.conn.WriteJSON(pingMsg{Event: "ping", ReqId: reqId})
+}
+
+// Watch checks liveness of the connection
+// Sends ping every interval and drops the connection if nothing was received from the server
+// for longer than timeout, the dropped connection is restored by the reading stream.
+// Blocks the goroutine until the client is closed.
+func (w *WsClient) Watch(interval, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		w.m.Lock()
+		closed := w.closed
+		w.m.Unlock()
+		if closed {
+			return
+		}
+		if w.State() != StateConnected {
+			continue
+		}
+		if silence := time.Since(w.LastSeen()); silence > timeout {
+			log.Printf("no messages from the server for %v, dropping connection", silence)
+			w.drop()
+			continue
+		}
+		if err := w.Ping(); err != nil {
+			log.Printf("cannot send ping: %v", err)
+		}
+	}
+}
+
+// drop closes current connection without closing the client, so the connection will be restored
+// The connection is forgotten, so requests fail with ErrNotConnected until the new one is made.
+func (w *WsClient) drop() {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.conn != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+}
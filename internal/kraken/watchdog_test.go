@@ -0,0 +1,131 @@
+package kraken
+
+import (
+	"errors"
+	"github.com/gorilla/websocket"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWsClient_seen(t *testing.T) {
+	tests := []struct {
+		name        string
+		pingSent    map[int]time.Time
+		msg         string
+		wantLatency bool
+		wantPending int
+	}{
+		{
+			name:        "heartbeat",
+			pingSent:    map[int]time.Time{1: time.Now()},
+			msg:         `{"event":"heartbeat"}`,
+			wantLatency: false,
+			wantPending: 1,
+		},
+		{
+			name:        "pong",
+			pingSent:    map[int]time.Time{1: time.Now().Add(-time.Millisecond * 10)},
+			msg:         `{"event":"pong","reqid":1}`,
+			wantLatency: true,
+			wantPending: 0,
+		},
+		{
+			name:        "unknown pong",
+			pingSent:    map[int]time.Time{1: time.Now()},
+			msg:         `{"event":"pong","reqid":2}`,
+			wantLatency: false,
+			wantPending: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWsClient("")
+			w.pingSent = tt.pingSent
+			before := time.Now()
+			w.seen([]byte(tt.msg))
+			if w.LastSeen().Before(before) {
+				t.Errorf("LastSeen() = %v, expected after %v", w.LastSeen(), before)
+			}
+			if got := w.Latency() >= time.Millisecond*10; got != tt.wantLatency {
+				t.Errorf("Latency() = %v, expected to be measured: %v", w.Latency(), tt.wantLatency)
+			}
+			if got := len(w.pingSent); got != tt.wantPending {
+				t.Errorf("pending pings = %d, want %d", got, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestWsClient_Watch(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(rw, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// the server reads pings but never answers
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	w := NewWsClient("ws" + strings.TrimPrefix(srv.URL, "http"))
+	w.Backoff = Backoff{Min: time.Millisecond, Max: time.Millisecond * 10}
+	states := &stateRecorder{}
+	w.States.Subscribe(states)
+	if err := w.Dial(); err != nil {
+		t.Fatalf("Dial() error: %v", err)
+	}
+	w.Stream()
+	go w.Watch(time.Millisecond*10, time.Millisecond*50)
+	time.Sleep(time.Millisecond * 200)
+	_ = w.Close()
+	got := states.get()
+	if len(got) < 3 || got[1] != StateReconnecting {
+		t.Errorf("States got %v, expected the silent connection to be restored", got)
+	}
+}
+
+func TestWsClient_drop(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(rw, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+	w := NewWsClient("ws" + strings.TrimPrefix(srv.URL, "http"))
+	// the stream before Dial is closed instead of reading a missing connection
+	if _, ok := <-w.Stream(); ok {
+		t.Errorf("Stream() before Dial() received a message")
+	}
+	if err := w.Dial(); err != nil {
+		t.Fatalf("Dial() error: %v", err)
+	}
+	defer w.Close()
+	w.drop()
+	if err := w.send(SubMessage{Event: "ping"}); !errors.Is(err, ErrNotConnected) {
+		t.Errorf("send() after drop() error = %v, want ErrNotConnected", err)
+	}
+}
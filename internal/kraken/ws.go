@@ -1,23 +1,100 @@
 package kraken
 
 import (
+	"bth-trader/internal/decimal"
 	"bth-trader/internal/entities"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/gorilla/websocket"
+	"github.com/ltunc/go-observer/observer"
 	"log"
+	"math/rand"
 	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 const WsEndpoint = "wss://ws-auth.kraken.com"
 
+// WsPublicEndpoint is the endpoint of public channels with market data, it does not require authentication
+const WsPublicEndpoint = "wss://ws.kraken.com"
+
+// ErrNotConnected is returned when a message is sent while there is no connection to the server
+var ErrNotConnected = errors.New("websocket is not connected")
+
+// errClosed is returned when the client was closed and should not connect again
+var errClosed = errors.New("client is closed")
+
+// ConnState is a state of the connection to the websocket server
+type ConnState int
+
+const (
+	StateDisconnected ConnState = iota
+	StateConnected
+	StateReconnecting
+	StateFailed
+)
+
+func (s ConnState) String() string {
+	switch s {
+	case StateConnected:
+		return "connected"
+	case StateReconnecting:
+		return "reconnecting"
+	case StateFailed:
+		return "failed"
+	}
+	return "disconnected"
+}
+
+// Backoff configures delays between reconnection attempts
+type Backoff struct {
+	Min time.Duration
+	Max time.Duration
+	// MaxAttempts is a number of attempts before the client gives up, 0 means retry forever
+	MaxAttempts int
+}
+
+// DefaultBackoff is used by new clients unless replaced
+var DefaultBackoff = Backoff{Min: time.Second, Max: time.Minute}
+
+// Delay returns time to wait before the attempt (counting from 0)
+// The delay grows exponentially up to Max, a random jitter takes up to half of the delay
+func (b Backoff) Delay(attempt int) time.Duration {
+	d := b.Min
+	for i := 0; i < attempt && d < b.Max; i++ {
+		d *= 2
+	}
+	if d > b.Max {
+		d = b.Max
+	}
+	if half := int64(d / 2); half > 0 {
+		d = time.Duration(half + rand.Int63n(half+1))
+	}
+	return d
+}
+
 type WsClient struct {
 	token    string
 	m        *sync.Mutex
 	endpoint string
 	conn     *websocket.Conn
 	output   chan json.RawMessage
+	subs     []SubMessage
+	state    ConnState
+	closed   bool
+	// lastSeen is time of the last received message in unix nanoseconds
+	lastSeen atomic.Int64
+	// latency is round-trip time of the last ping in nanoseconds
+	latency  atomic.Int64
+	pingSent map[int]time.Time
+	requests *Requests
+	Backoff  Backoff
+	// States notifies observers about changes of the connection state
+	States *observer.Subject[ConnState]
 }
 
 type SubMessage struct {
@@ -26,6 +103,25 @@ type SubMessage struct {
 	Subscription map[string]any `json:"subscription,omitempty"`
 }
 
+// sameChannel checks if both messages are related to the same channel and pairs
+// Channels with options, e.g. ohlc with different intervals, are different channels.
+func (s SubMessage) sameChannel(other SubMessage) bool {
+	if s.Subscription["name"] != other.Subscription["name"] || len(s.Pair) != len(other.Pair) {
+		return false
+	}
+	for _, option := range []string{"interval", "depth"} {
+		if fmt.Sprint(s.Subscription[option]) != fmt.Sprint(other.Subscription[option]) {
+			return false
+		}
+	}
+	for k := range s.Pair {
+		if s.Pair[k] != other.Pair[k] {
+			return false
+		}
+	}
+	return true
+}
+
 // NewWsClient creates new Websocket client
 // Argument endpoint should be full address of the server to connect to
 // The returned client will not be connected to the server. To do so call method *WsClient.Dial()
@@ -33,6 +129,10 @@ func NewWsClient(endpoint string) *WsClient {
 	return &WsClient{
 		endpoint: endpoint,
 		m:        &sync.Mutex{},
+		pingSent: make(map[int]time.Time),
+		requests: NewRequests(),
+		Backoff:  DefaultBackoff,
+		States:   &observer.Subject[ConnState]{},
 	}
 }
 
@@ -40,93 +140,358 @@ func NewWsClient(endpoint string) *WsClient {
 // Takes address to connect from WsClient.endpoint property
 // Returns original errors
 func (w *WsClient) Dial() error {
+	if err := w.dial(); err != nil {
+		return err
+	}
+	w.setState(StateConnected)
+	return nil
+}
+
+// dial connects unless the client is already connected
+// The lock is not held while dialing, so Close is not blocked by a slow server.
+func (w *WsClient) dial() error {
 	w.m.Lock()
-	defer w.m.Unlock()
+	if w.closed {
+		w.m.Unlock()
+		return errClosed
+	}
 	if w.conn != nil {
+		w.m.Unlock()
 		return nil
 	}
+	w.m.Unlock()
 	log.Printf("dialing kraken server: %s", w.endpoint)
-	var err error
-	if w.conn, _, err = websocket.DefaultDialer.Dial(w.endpoint, nil); err != nil {
+	conn, _, err := websocket.DefaultDialer.Dial(w.endpoint, nil)
+	if err != nil {
 		return err
 	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.closed || w.conn != nil {
+		// Close was called or another connection was made while dialing
+		_ = conn.Close()
+		if w.closed {
+			return errClosed
+		}
+		return nil
+	}
+	w.conn = conn
+	w.lastSeen.Store(time.Now().UnixNano())
 	return nil
 }
 
+// Close closes the connection, the client will not try to restore it
+func (w *WsClient) Close() error {
+	w.m.Lock()
+	w.closed = true
+	conn := w.conn
+	w.conn = nil
+	w.m.Unlock()
+	w.setState(StateDisconnected)
+	if conn == nil {
+		return nil
+	}
+	return conn.Close()
+}
+
+// Requests returns registry of requests waiting for replies from the server
+// Replies should be routed to the registry by the reader of the stream
+func (w *WsClient) Requests() *Requests {
+	return w.requests
+}
+
+// State returns current state of the connection
+func (w *WsClient) State() ConnState {
+	w.m.Lock()
+	defer w.m.Unlock()
+	return w.state
+}
+
+func (w *WsClient) setState(s ConnState) {
+	w.m.Lock()
+	changed := w.state != s
+	w.state = s
+	w.m.Unlock()
+	if changed {
+		w.States.Fire(s)
+	}
+}
+
 // Subscribe sends "subscribe" event to the server
-// The connection to WS server should be alive.
+// Public subscriptions are remembered and sent again after the connection is restored,
+// "unsubscribe" event removes the subscription for the same channel.
+// If the client is not connected a public subscription will be sent only after reconnection,
+// private ones (with a token) should be made again with a fresh token.
 // Returns original error wrapped with more descriptions
 func (w *WsClient) Subscribe(sub SubMessage) error {
 	w.m.Lock()
 	defer w.m.Unlock()
+	for k, s := range w.subs {
+		if s.sameChannel(sub) {
+			w.subs = append(w.subs[:k], w.subs[k+1:]...)
+			break
+		}
+	}
+	if sub.Event == "subscribe" {
+		w.subs = append(w.subs, sub)
+	}
+	if w.conn == nil {
+		return nil
+	}
 	if err := w.conn.WriteJSON(sub); err != nil {
 		return fmt.Errorf("cannot send subscribe message: %w", err)
 	}
 	return nil
 }
 
+// resubscribe sends again all remembered public subscriptions
+// Private subscriptions are not repeated, the remembered token may be expired,
+// they should be made again by the owner of the token.
+func (w *WsClient) resubscribe() error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.conn == nil {
+		return ErrNotConnected
+	}
+	for _, sub := range w.subs {
+		if _, private := sub.Subscription["token"]; private {
+			continue
+		}
+		if err := w.conn.WriteJSON(sub); err != nil {
+			return fmt.Errorf("cannot send subscribe message: %w", err)
+		}
+	}
+	return nil
+}
+
+// send writes the message to the connection
+func (w *WsClient) send(msg any) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.conn == nil {
+		return ErrNotConnected
+	}
+	return w.conn.WriteJSON(msg)
+}
+
 // Stream starts reading messages from websocket connection
 // Returns a channel to which it sends all received messages.
 // Can be called multiple times, but creates channel only first time,
 // subsequent calls will return already opened channel.
-// Closes the output channel if the websocket server returned an error.
+// When the connection is lost the client reconnects and keeps sending messages to the same channel.
+// Closes the output channel if the client was closed or cannot restore the connection.
+// Returns a closed channel if the client is not connected yet, Dial should be called before.
 func (w *WsClient) Stream() <-chan json.RawMessage {
 	w.m.Lock()
 	defer w.m.Unlock()
 	if w.output != nil {
 		return w.output
 	}
+	conn := w.conn
+	if conn == nil {
+		log.Printf("cannot read websocket stream: %v", ErrNotConnected)
+		closed := make(chan json.RawMessage)
+		close(closed)
+		return closed
+	}
 	w.output = make(chan json.RawMessage, 100)
 	go func() {
 		for {
-			_, msg, err := w.conn.ReadMessage()
+			_, msg, err := conn.ReadMessage()
 			if err != nil {
-				close(w.output)
 				log.Printf("websocket read error: %v", err)
-				return
+				if conn, err = w.reconnect(conn); err != nil {
+					log.Printf("websocket stream stopped: %v", err)
+					close(w.output)
+					return
+				}
+				continue
 			}
+			w.seen(msg)
 			w.output <- msg
 		}
 	}()
 	return w.output
 }
 
+// reconnect replaces broken connection with a new one and restores all subscriptions
+// Waits between attempts according to the Backoff settings
+func (w *WsClient) reconnect(broken *websocket.Conn) (*websocket.Conn, error) {
+	w.m.Lock()
+	if w.closed {
+		w.m.Unlock()
+		return nil, errClosed
+	}
+	if w.conn == broken {
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+	w.m.Unlock()
+	w.setState(StateReconnecting)
+	for attempt := 0; w.Backoff.MaxAttempts == 0 || attempt < w.Backoff.MaxAttempts; attempt++ {
+		time.Sleep(w.Backoff.Delay(attempt))
+		if err := w.dial(); errors.Is(err, errClosed) {
+			return nil, err
+		} else if err != nil {
+			log.Printf("reconnection attempt %d failed: %v", attempt+1, err)
+			continue
+		}
+		if err := w.resubscribe(); err != nil {
+			log.Printf("reconnection attempt %d failed: %v", attempt+1, err)
+			w.m.Lock()
+			if w.conn != nil {
+				_ = w.conn.Close()
+				w.conn = nil
+			}
+			w.m.Unlock()
+			continue
+		}
+		w.m.Lock()
+		conn := w.conn
+		closed := w.closed
+		w.m.Unlock()
+		if closed {
+			return nil, errClosed
+		}
+		w.setState(StateConnected)
+		return conn, nil
+	}
+	w.setState(StateFailed)
+	return nil, fmt.Errorf("cannot reconnect after %d attempts", w.Backoff.MaxAttempts)
+}
+
 type AddOrderMsg struct {
-	Event     string `json:"event"`
-	OrderType string `json:"ordertype"`
-	Pair      string `json:"pair"`
-	Price     string `json:"price"`
-	ReqId     int    `json:"reqid,omitempty"`
-	Token     string `json:"token"`
-	Type      string `json:"type"`
-	UserRef   string `json:"userref"`
-	Volume    string `json:"volume"`
-}
-
-func NewAddOrderMsg(refId int, pair, direction string, price, volume float64, token string) AddOrderMsg {
-	msg := AddOrderMsg{
-		Event:     "addOrder",
-		OrderType: "limit",
+	Event       string `json:"event"`
+	OrderType   string `json:"ordertype"`
+	Pair        string `json:"pair"`
+	Price       string `json:"price,omitempty"`
+	Price2      string `json:"price2,omitempty"`
+	Trigger     string `json:"trigger,omitempty"`
+	Leverage    string `json:"leverage,omitempty"`
+	OFlags      string `json:"oflags,omitempty"`
+	TimeInForce string `json:"timeinforce,omitempty"`
+	StartTm     string `json:"starttm,omitempty"`
+	ExpireTm    string `json:"expiretm,omitempty"`
+	Deadline    string `json:"deadline,omitempty"`
+	ReduceOnly  bool   `json:"reduce_only,omitempty"`
+	Validate    string `json:"validate,omitempty"`
+	ReqId       int    `json:"reqid,omitempty"`
+	Token       string `json:"token"`
+	Type        string `json:"type"`
+	UserRef     string `json:"userref"`
+	ClOrdId     string `json:"cl_ord_id,omitempty"`
+	Volume      string `json:"volume"`
+}
+
+// NewAddOrderMsg creates a message for a limit order
+func NewAddOrderMsg(refId int, pair, direction string, price, volume decimal.Decimal, token string) AddOrderMsg {
+	params := OrderParams{
 		Pair:      pair,
-		Price:     fmt.Sprintf("%v", price),
-		ReqId:     refId,
-		Token:     token,
-		Type:      direction,
-		UserRef:   strconv.Itoa(refId),
-		Volume:    fmt.Sprintf("%v", volume),
+		Direction: direction,
+		OrderType: "limit",
+		Price:     price,
+		Volume:    volume,
+	}
+	return NewOrderMsg(refId, params, token)
+}
+
+// NewOrderMsg creates a message for an order of any type
+// The parameters should be validated with OrderParams.Validate before
+func NewOrderMsg(refId int, p OrderParams, token string) AddOrderMsg {
+	msg := AddOrderMsg{
+		Event:       "addOrder",
+		OrderType:   p.OrderType,
+		Pair:        p.Pair,
+		Price:       formatPrice(p.Price, p.PriceOffset, p.PricePercent),
+		Price2:      formatPrice(p.Price2, p.Price2Offset, p.Price2Percent),
+		Trigger:     p.Trigger,
+		Leverage:    p.Leverage,
+		OFlags:      formatFlags(p.OFlags),
+		TimeInForce: p.TimeInForce,
+		StartTm:     p.StartTm,
+		ExpireTm:    p.ExpireTm,
+		Deadline:    p.Deadline,
+		ReduceOnly:  p.ReduceOnly,
+		Token:       token,
+		Type:        p.Direction,
+		UserRef:     strconv.Itoa(refId),
+		ClOrdId:     p.ClientOrderId,
+		Volume:      p.Volume.String(),
+	}
+	if p.ValidateOnly {
+		msg.Validate = "true"
 	}
 	return msg
 }
 
+// AddOrder sends the order to the server
+// Does not wait for the reply, the reply is delivered with the orders stream with RefId of the order
 func (w *WsClient) AddOrder(msg AddOrderMsg) error {
-	w.m.Lock()
-	defer w.m.Unlock()
-	if err := w.conn.WriteJSON(msg); err != nil {
+	refId, _ := strconv.Atoi(msg.UserRef)
+	reqId, _ := w.requests.Register(refId)
+	msg.ReqId = reqId
+	if err := w.send(msg); err != nil {
+		w.requests.Forget(reqId)
 		return fmt.Errorf("cannot send addOrder message: %w", err)
 	}
 	return nil
 }
 
+// ValidateOrder sends the order with "validate" flag and waits for the reply
+// Waiting stops when the context is done, the reply is ignored then.
+// The exchange only checks the order without placing it, so the reply is not delivered to the orders stream
+func (w *WsClient) ValidateOrder(ctx context.Context, msg AddOrderMsg) (Reply, error) {
+	reqId, replies := w.requests.Register(0)
+	defer w.requests.Forget(reqId)
+	msg.ReqId = reqId
+	msg.Validate = "true"
+	if err := w.send(msg); err != nil {
+		return Reply{}, fmt.Errorf("cannot send addOrder message: %w", err)
+	}
+	return Wait(ctx, replies, RequestTimeout)
+}
+
+type EditOrderMsg struct {
+	Event      string `json:"event"`
+	OrderId    string `json:"orderid"`
+	Pair       string `json:"pair"`
+	Price      string `json:"price,omitempty"`
+	Price2     string `json:"price2,omitempty"`
+	Volume     string `json:"volume,omitempty"`
+	NewUserRef string `json:"newuserref,omitempty"`
+	ReqId      int    `json:"reqid,omitempty"`
+	Token      string `json:"token"`
+}
+
+// NewEditOrderMsg creates a message to change price or volume of the order
+// The replacement order keeps refId of the original one, zero values are not changed
+func NewEditOrderMsg(order *entities.Order, pair string, price, price2, volume decimal.Decimal, token string) EditOrderMsg {
+	msg := EditOrderMsg{
+		Event:      "editOrder",
+		OrderId:    order.OrderId,
+		Pair:       pair,
+		Price:      formatPrice(price, "", false),
+		Price2:     formatPrice(price2, "", false),
+		Volume:     formatPrice(volume, "", false),
+		NewUserRef: strconv.Itoa(order.RefId),
+		Token:      token,
+	}
+	return msg
+}
+
+// EditOrder sends the request to edit an order and waits for the reply
+// The reply has TxId of the replacement order and OriginalTxId of the edited one
+func (w *WsClient) EditOrder(ctx context.Context, msg EditOrderMsg) (Reply, error) {
+	refId, _ := strconv.Atoi(msg.NewUserRef)
+	reqId, replies := w.requests.Register(refId)
+	defer w.requests.Forget(reqId)
+	msg.ReqId = reqId
+	if err := w.send(msg); err != nil {
+		return Reply{}, fmt.Errorf("cannot send editOrder message: %w", err)
+	}
+	return Wait(ctx, replies, RequestTimeout)
+}
+
 type CancelOrderMsg struct {
 	Event string   `json:"event"`
 	ReqId int      `json:"reqid,omitempty"`
@@ -147,11 +512,58 @@ func NewCancelOrderMsg(ord []*entities.Order, token string) CancelOrderMsg {
 	return msg
 }
 
-func (w *WsClient) CancelOrder(msg CancelOrderMsg) error {
-	w.m.Lock()
-	defer w.m.Unlock()
-	if err := w.conn.WriteJSON(msg); err != nil {
-		return fmt.Errorf("cannot send cancelOrder message: %w", err)
+type CancelAllMsg struct {
+	Event string `json:"event"`
+	ReqId int    `json:"reqid,omitempty"`
+	Token string `json:"token"`
+}
+
+func NewCancelAllMsg(token string) CancelAllMsg {
+	return CancelAllMsg{Event: "cancelAll", Token: token}
+}
+
+// CancelAll sends the request to cancel all open orders and waits for the reply
+// The reply has number of canceled orders in Count
+func (w *WsClient) CancelAll(ctx context.Context, msg CancelAllMsg) (Reply, error) {
+	reqId, replies := w.requests.Register(0)
+	defer w.requests.Forget(reqId)
+	msg.ReqId = reqId
+	if err := w.send(msg); err != nil {
+		return Reply{}, fmt.Errorf("cannot send cancelAll message: %w", err)
 	}
-	return nil
+	return Wait(ctx, replies, RequestTimeout)
+}
+
+type CancelAllAfterMsg struct {
+	Event   string `json:"event"`
+	ReqId   int    `json:"reqid,omitempty"`
+	Timeout int    `json:"timeout"`
+	Token   string `json:"token"`
+}
+
+// NewCancelAllAfterMsg creates a message to cancel all orders after timeout, zero timeout disables the countdown
+func NewCancelAllAfterMsg(timeout time.Duration, token string) CancelAllAfterMsg {
+	return CancelAllAfterMsg{Event: "cancelAllOrdersAfter", Timeout: int(timeout.Seconds()), Token: token}
+}
+
+// CancelAllAfter sends the request to (re)start the countdown to cancel all orders and waits for the reply
+func (w *WsClient) CancelAllAfter(ctx context.Context, msg CancelAllAfterMsg) (Reply, error) {
+	reqId, replies := w.requests.Register(0)
+	defer w.requests.Forget(reqId)
+	msg.ReqId = reqId
+	if err := w.send(msg); err != nil {
+		return Reply{}, fmt.Errorf("cannot send cancelAllOrdersAfter message: %w", err)
+	}
+	return Wait(ctx, replies, RequestTimeout)
+}
+
+// CancelOrder sends the request to cancel orders and waits for the reply
+func (w *WsClient) CancelOrder(ctx context.Context, msg CancelOrderMsg) (Reply, error) {
+	reqId, replies := w.requests.Register(0)
+	defer w.requests.Forget(reqId)
+	msg.ReqId = reqId
+	if err := w.send(msg); err != nil {
+		return Reply{}, fmt.Errorf("cannot send cancelOrder message: %w", err)
+	}
+	return Wait(ctx, replies, RequestTimeout)
 }
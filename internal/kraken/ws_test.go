@@ -1,8 +1,21 @@
 package kraken
 
 import (
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/entities"
+	"errors"
+	"github.com/gorilla/websocket"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestNewAddOrderMsg(t *testing.T) {
@@ -10,8 +23,8 @@ func TestNewAddOrderMsg(t *testing.T) {
 		refId     int
 		pair      string
 		direction string
-		price     float64
-		volume    float64
+		price     decimal.Decimal
+		volume    decimal.Decimal
 		token     string
 	}
 	tests := []struct {
@@ -25,8 +38,8 @@ func TestNewAddOrderMsg(t *testing.T) {
 				refId:     1234567,
 				pair:      "XBT/USD",
 				direction: "sell",
-				price:     22110.19,
-				volume:    0.00235101,
+				price:     decimal.MustParse("22110.19"),
+				volume:    decimal.MustParse("0.00235101"),
 				token:     "some-token",
 			},
 			want: AddOrderMsg{
@@ -34,13 +47,33 @@ func TestNewAddOrderMsg(t *testing.T) {
 				OrderType: "limit",
 				Pair:      "XBT/USD",
 				Price:     "22110.19",
-				ReqId:     1234567,
 				Token:     "some-token",
 				Type:      "sell",
 				UserRef:   "1234567",
 				Volume:    "0.00235101",
 			},
 		},
+		{
+			name: "small volume without exponent",
+			args: args{
+				refId:     1234568,
+				pair:      "XBT/USD",
+				direction: "buy",
+				price:     decimal.MustParse("21000"),
+				volume:    decimal.MustParse("0.00001"),
+				token:     "some-token",
+			},
+			want: AddOrderMsg{
+				Event:     "addOrder",
+				OrderType: "limit",
+				Pair:      "XBT/USD",
+				Price:     "21000",
+				Token:     "some-token",
+				Type:      "buy",
+				UserRef:   "1234568",
+				Volume:    "0.00001",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -50,3 +83,200 @@ func TestNewAddOrderMsg(t *testing.T) {
 		})
 	}
 }
+
+func TestNewEditOrderMsg(t *testing.T) {
+	order := &entities.Order{OrderId: "OABCDE-ABCD2-ABCDE3", RefId: 1234567}
+	tests := []struct {
+		name   string
+		price  decimal.Decimal
+		price2 decimal.Decimal
+		volume decimal.Decimal
+		want   EditOrderMsg
+	}{
+		{
+			name:  "price",
+			price: decimal.MustParse("22110.19"),
+			want: EditOrderMsg{
+				Event: "editOrder", OrderId: "OABCDE-ABCD2-ABCDE3", Pair: "XBT/USD", Price: "22110.19",
+				NewUserRef: "1234567", Token: "some-token",
+			},
+		},
+		{
+			name:   "volume",
+			volume: decimal.MustParse("0.5"),
+			want: EditOrderMsg{
+				Event: "editOrder", OrderId: "OABCDE-ABCD2-ABCDE3", Pair: "XBT/USD", Volume: "0.5",
+				NewUserRef: "1234567", Token: "some-token",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewEditOrderMsg(order, "XBT/USD", tt.price, tt.price2, tt.volume, "some-token"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewEditOrderMsg() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff_Delay(t *testing.T) {
+	b := Backoff{Min: time.Second, Max: time.Second * 10}
+	tests := []struct {
+		name    string
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{"first", 0, time.Millisecond * 500, time.Second},
+		{"second", 1, time.Second, time.Second * 2},
+		{"third", 2, time.Second * 2, time.Second * 4},
+		{"limited", 10, time.Second * 5, time.Second * 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				if got := b.Delay(tt.attempt); got < tt.min || got > tt.max {
+					t.Errorf("Delay() = %v, want between %v and %v", got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestSubMessage_sameChannel(t *testing.T) {
+	ohlc := SubMessage{Event: "subscribe", Pair: []string{"XBT/EUR"}, Subscription: map[string]any{"name": "ohlc", "interval": 5}}
+	tests := []struct {
+		name  string
+		other SubMessage
+		want  bool
+	}{
+		{"unsubscribe", SubMessage{Event: "unsubscribe", Pair: []string{"XBT/EUR"}, Subscription: map[string]any{"name": "ohlc", "interval": 5}}, true},
+		{"other interval", SubMessage{Event: "subscribe", Pair: []string{"XBT/EUR"}, Subscription: map[string]any{"name": "ohlc", "interval": 15}}, false},
+		{"other pair", SubMessage{Event: "subscribe", Pair: []string{"ETH/EUR"}, Subscription: map[string]any{"name": "ohlc", "interval": 5}}, false},
+		{"other channel", SubMessage{Event: "subscribe", Pair: []string{"XBT/EUR"}, Subscription: map[string]any{"name": "ticker"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ohlc.sameChannel(tt.other); got != tt.want {
+				t.Errorf("sameChannel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWsClient_Reconnect(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	received := make(chan SubMessage, 10)
+	var connections int32
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(rw, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		n := atomic.AddInt32(&connections, 1)
+		var sub SubMessage
+		if err := conn.ReadJSON(&sub); err != nil {
+			return
+		}
+		received <- sub
+		if n == 1 {
+			// drop the first connection
+			return
+		}
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"event":"heartbeat"}`))
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer srv.Close()
+
+	w := NewWsClient("ws" + strings.TrimPrefix(srv.URL, "http"))
+	w.Backoff = Backoff{Min: time.Millisecond, Max: time.Millisecond * 10}
+	states := &stateRecorder{}
+	w.States.Subscribe(states)
+	// private subscriptions are not repeated after reconnection, the token may be expired
+	private := SubMessage{Event: "subscribe", Subscription: map[string]any{"name": "ownTrades", "token": "token"}}
+	if err := w.Subscribe(private); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	if err := w.Dial(); err != nil {
+		t.Fatalf("Dial() error: %v", err)
+	}
+	defer w.Close()
+	sub := SubMessage{Event: "subscribe", Subscription: map[string]any{"name": "openOrders"}}
+	if err := w.Subscribe(sub); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	out := w.Stream()
+	select {
+	case msg := <-out:
+		if string(msg) != `{"event":"heartbeat"}` {
+			t.Errorf("Stream() got %s", msg)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("Stream() no messages after reconnection")
+	}
+	if len(received) != 2 {
+		t.Errorf("expected subscription to be sent twice, got %d", len(received))
+	}
+	for len(received) > 0 {
+		if got := <-received; got.Subscription["name"] != "openOrders" {
+			t.Errorf("received subscription %v, want openOrders", got)
+		}
+	}
+	want := []ConnState{StateConnected, StateReconnecting, StateConnected}
+	if got := states.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("States got %v, want %v", got, want)
+	}
+}
+
+func TestWsClient_CloseWhileDialing(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	var w *WsClient
+	dropped := make(chan struct{})
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		// the client is closed before the connection is established
+		_ = w.Close()
+		conn, err := upgrader.Upgrade(rw, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _, _ = conn.ReadMessage()
+		close(dropped)
+	}))
+	defer srv.Close()
+
+	w = NewWsClient("ws" + strings.TrimPrefix(srv.URL, "http"))
+	if err := w.Dial(); !errors.Is(err, errClosed) {
+		t.Errorf("Dial() error = %v, want %v", err, errClosed)
+	}
+	select {
+	case <-dropped:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("the connection made after Close() was not closed")
+	}
+	if w.State() != StateDisconnected {
+		t.Errorf("State() = %v, want %v", w.State(), StateDisconnected)
+	}
+}
+
+type stateRecorder struct {
+	mu     sync.Mutex
+	states []ConnState
+}
+
+func (s *stateRecorder) Notify(st ConnState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states = append(s.states, st)
+}
+
+func (s *stateRecorder) get() []ConnState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ConnState(nil), s.states...)
+}
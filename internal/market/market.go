@@ -0,0 +1,36 @@
+package market
+
+import (
+	"bth-trader/internal/entities"
+	"github.com/ltunc/go-observer/observer"
+)
+
+// Market contains dispatchers of market data received from public channels
+// and subscriptions to these channels
+type Market struct {
+	Tickers *observer.Subject[*entities.Ticker]
+	Trades  *observer.Subject[*entities.PublicTrade]
+	Spreads *observer.Subject[*entities.Spread]
+	Candles *observer.Subject[*entities.Candle]
+	Books   *observer.Subject[*entities.BookUpdate]
+	Subs    *Subscriptions
+}
+
+// NewMarket creates dispatchers of market data, subscriptions are sent to kraken by ws
+func NewMarket(ws Subscriber) *Market {
+	return &Market{
+		Tickers: &observer.Subject[*entities.Ticker]{},
+		Trades:  &observer.Subject[*entities.PublicTrade]{},
+		Spreads: &observer.Subject[*entities.Spread]{},
+		Candles: &observer.Subject[*entities.Candle]{},
+		Books:   &observer.Subject[*entities.BookUpdate]{},
+		Subs:    NewSubscriptions(ws),
+	}
+}
+
+// ReadFrom reads market data from the channel and fires events in the dispatcher
+func ReadFrom[T any](dispatcher *observer.Subject[T], input <-chan T) {
+	for v := range input {
+		dispatcher.Fire(v)
+	}
+}
@@ -0,0 +1,155 @@
+package market
+
+import (
+	"bth-trader/internal/kraken"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Public channels of kraken with market data
+const (
+	ChannelTicker = "ticker"
+	ChannelTrade  = "trade"
+	ChannelSpread = "spread"
+	ChannelOHLC   = "ohlc"
+	ChannelBook   = "book"
+)
+
+// Intervals are lengths of candles in minutes supported by kraken
+var Intervals = []int{1, 5, 15, 30, 60, 240, 1440, 10080, 21600}
+
+// Depths are numbers of price levels of order books supported by kraken
+var Depths = []int{10, 25, 100, 500, 1000}
+
+// ErrInvalidTopic is returned for a topic without pair, with unknown channel, unsupported interval or depth
+var ErrInvalidTopic = errors.New("invalid topic")
+
+// Subscriber sends subscription messages to kraken, e.g. *kraken.WsClient
+type Subscriber interface {
+	Subscribe(sub kraken.SubMessage) error
+}
+
+// Topic is a public channel of a single pair
+type Topic struct {
+	Channel string
+	Pair    string
+	// Interval is the length of candles in minutes, it is used only by the ohlc channel
+	Interval int
+	// Depth is number of price levels, it is used only by the book channel
+	Depth int
+}
+
+func (t Topic) validate() error {
+	if t.Pair == "" {
+		return fmt.Errorf("%w: pair is required", ErrInvalidTopic)
+	}
+	switch t.Channel {
+	case ChannelTicker, ChannelTrade, ChannelSpread:
+		return nil
+	case ChannelOHLC:
+		for _, i := range Intervals {
+			if t.Interval == i {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: unsupported interval %d", ErrInvalidTopic, t.Interval)
+	case ChannelBook:
+		for _, d := range Depths {
+			if t.Depth == d {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: unsupported depth %d", ErrInvalidTopic, t.Depth)
+	}
+	return fmt.Errorf("%w: unknown channel %q", ErrInvalidTopic, t.Channel)
+}
+
+// message creates subscribe or unsubscribe message for the topic
+func (t Topic) message(event string) kraken.SubMessage {
+	sub := map[string]any{"name": t.Channel}
+	if t.Channel == ChannelOHLC {
+		sub["interval"] = t.Interval
+	}
+	if t.Channel == ChannelBook {
+		sub["depth"] = t.Depth
+	}
+	return kraken.SubMessage{Event: event, Pair: []string{t.Pair}, Subscription: sub}
+}
+
+// Subscriptions counts listeners of topics, kraken is subscribed to a topic only while it has listeners
+type Subscriptions struct {
+	ws     Subscriber
+	counts map[Topic]int
+	mu     *sync.Mutex
+}
+
+// NewSubscriptions creates subscriptions which are sent to kraken by ws
+func NewSubscriptions(ws Subscriber) *Subscriptions {
+	return &Subscriptions{
+		ws:     ws,
+		counts: make(map[Topic]int),
+		mu:     &sync.Mutex{},
+	}
+}
+
+// Acquire adds a listener of the topic, kraken is subscribed to the topic for the first listener
+// Every successful call should be followed by Release when the listener leaves.
+func (s *Subscriptions) Acquire(t Topic) error {
+	if err := t.validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counts[t] == 0 {
+		if err := s.ws.Subscribe(t.message("subscribe")); err != nil {
+			// the client remembers the subscription, it should not be restored without listeners
+			_ = s.ws.Subscribe(t.message("unsubscribe"))
+			return fmt.Errorf("cannot subscribe to %s of %s: %w", t.Channel, t.Pair, err)
+		}
+	}
+	s.counts[t]++
+	return nil
+}
+
+// Release removes a listener of the topic, kraken is unsubscribed from the topic after the last listener
+func (s *Subscriptions) Release(t Topic) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counts[t] == 0 {
+		return nil
+	}
+	s.counts[t]--
+	if s.counts[t] > 0 {
+		return nil
+	}
+	delete(s.counts, t)
+	if err := s.ws.Subscribe(t.message("unsubscribe")); err != nil {
+		return fmt.Errorf("cannot unsubscribe from %s of %s: %w", t.Channel, t.Pair, err)
+	}
+	return nil
+}
+
+// Resubscribe sends unsubscribe and subscribe messages for the topic if it has listeners
+// Kraken sends a new snapshot after subscription, e.g. to restore an order book which is out of sync.
+func (s *Subscriptions) Resubscribe(t Topic) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counts[t] == 0 {
+		return nil
+	}
+	if err := s.ws.Subscribe(t.message("unsubscribe")); err != nil {
+		return fmt.Errorf("cannot unsubscribe from %s of %s: %w", t.Channel, t.Pair, err)
+	}
+	if err := s.ws.Subscribe(t.message("subscribe")); err != nil {
+		return fmt.Errorf("cannot subscribe to %s of %s: %w", t.Channel, t.Pair, err)
+	}
+	return nil
+}
+
+// Listeners returns number of listeners of the topic
+func (s *Subscriptions) Listeners(t Topic) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counts[t]
+}
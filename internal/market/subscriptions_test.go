@@ -0,0 +1,120 @@
+package market
+
+import (
+	"bth-trader/internal/kraken"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type mockSubscriber struct {
+	sent []kraken.SubMessage
+	err  error
+}
+
+func (m *mockSubscriber) Subscribe(sub kraken.SubMessage) error {
+	m.sent = append(m.sent, sub)
+	if sub.Event == "subscribe" {
+		return m.err
+	}
+	return nil
+}
+
+func TestSubscriptions_AcquireRelease(t *testing.T) {
+	ws := &mockSubscriber{}
+	subs := NewSubscriptions(ws)
+	ticker := Topic{Channel: ChannelTicker, Pair: "XBT/EUR"}
+	candles := Topic{Channel: ChannelOHLC, Pair: "XBT/EUR", Interval: 5}
+	for _, topic := range []Topic{ticker, ticker, candles} {
+		if err := subs.Acquire(topic); err != nil {
+			t.Fatalf("Acquire(%v) error = %v", topic, err)
+		}
+	}
+	if got := subs.Listeners(ticker); got != 2 {
+		t.Errorf("Listeners() = %d, want 2", got)
+	}
+	for _, topic := range []Topic{ticker, candles, ticker, ticker} {
+		if err := subs.Release(topic); err != nil {
+			t.Fatalf("Release(%v) error = %v", topic, err)
+		}
+	}
+	want := []kraken.SubMessage{
+		{Event: "subscribe", Pair: []string{"XBT/EUR"}, Subscription: map[string]any{"name": "ticker"}},
+		{Event: "subscribe", Pair: []string{"XBT/EUR"}, Subscription: map[string]any{"name": "ohlc", "interval": 5}},
+		{Event: "unsubscribe", Pair: []string{"XBT/EUR"}, Subscription: map[string]any{"name": "ohlc", "interval": 5}},
+		{Event: "unsubscribe", Pair: []string{"XBT/EUR"}, Subscription: map[string]any{"name": "ticker"}},
+	}
+	if !reflect.DeepEqual(ws.sent, want) {
+		t.Errorf("sent messages = %v, want %v", ws.sent, want)
+	}
+	if got := subs.Listeners(ticker); got != 0 {
+		t.Errorf("Listeners() = %d after release, want 0", got)
+	}
+}
+
+func TestSubscriptions_AcquireFailed(t *testing.T) {
+	ws := &mockSubscriber{err: errors.New("broken pipe")}
+	subs := NewSubscriptions(ws)
+	topic := Topic{Channel: ChannelSpread, Pair: "XBT/EUR"}
+	if err := subs.Acquire(topic); err == nil {
+		t.Fatalf("Acquire() expected error")
+	}
+	if got := subs.Listeners(topic); got != 0 {
+		t.Errorf("Listeners() = %d, want 0", got)
+	}
+	if last := ws.sent[len(ws.sent)-1]; last.Event != "unsubscribe" {
+		t.Errorf("failed subscription should be forgotten, last message %v", last)
+	}
+}
+
+func TestSubscriptions_Resubscribe(t *testing.T) {
+	ws := &mockSubscriber{}
+	subs := NewSubscriptions(ws)
+	book := Topic{Channel: ChannelBook, Pair: "XBT/EUR", Depth: 10}
+	if err := subs.Resubscribe(book); err != nil || len(ws.sent) > 0 {
+		t.Fatalf("Resubscribe() without listeners sent %v, error = %v", ws.sent, err)
+	}
+	if err := subs.Acquire(book); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if err := subs.Resubscribe(book); err != nil {
+		t.Fatalf("Resubscribe() error = %v", err)
+	}
+	msg := func(event string) kraken.SubMessage {
+		return kraken.SubMessage{Event: event, Pair: []string{"XBT/EUR"}, Subscription: map[string]any{"name": "book", "depth": 10}}
+	}
+	want := []kraken.SubMessage{msg("subscribe"), msg("unsubscribe"), msg("subscribe")}
+	if !reflect.DeepEqual(ws.sent, want) {
+		t.Errorf("sent messages = %v, want %v", ws.sent, want)
+	}
+	if got := subs.Listeners(book); got != 1 {
+		t.Errorf("Listeners() = %d, want 1", got)
+	}
+}
+
+func TestTopic_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		topic   Topic
+		wantErr bool
+	}{
+		{"ticker", Topic{Channel: ChannelTicker, Pair: "XBT/EUR"}, false},
+		{"candles", Topic{Channel: ChannelOHLC, Pair: "XBT/EUR", Interval: 60}, false},
+		{"no pair", Topic{Channel: ChannelTrade}, true},
+		{"book", Topic{Channel: ChannelBook, Pair: "XBT/EUR", Depth: 25}, false},
+		{"unknown channel", Topic{Channel: "orders", Pair: "XBT/EUR"}, true},
+		{"unsupported depth", Topic{Channel: ChannelBook, Pair: "XBT/EUR", Depth: 20}, true},
+		{"unsupported interval", Topic{Channel: ChannelOHLC, Pair: "XBT/EUR", Interval: 2}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.topic.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidTopic) {
+				t.Errorf("validate() error = %v, want ErrInvalidTopic", err)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package orders
 
 import (
 	"bth-trader/internal/entities"
+	"context"
 	"github.com/ltunc/go-observer/observer"
 )
 
@@ -24,6 +25,7 @@ type Waiter struct {
 }
 
 // NewWaiter creates an observer that blocks a goroutine until update for an order with refId received
+// It should be subscribed to updates of the storage, so it receives merged states of orders accepted by the storage.
 func NewWaiter(refId int) *Waiter {
 	return &Waiter{
 		expectRefId: refId,
@@ -31,8 +33,10 @@ func NewWaiter(refId int) *Waiter {
 	}
 }
 
+// Notify receives the order if it has the expected refId
+// Pending state is not a confirmation of the order, so it is skipped.
 func (w *Waiter) Notify(o *entities.Order) {
-	if o.RefId == w.expectRefId {
+	if o.RefId == w.expectRefId && o.Status != "pending" {
 		select {
 		case w.results <- o:
 		default:
@@ -41,8 +45,13 @@ func (w *Waiter) Notify(o *entities.Order) {
 	}
 }
 
-// Wait blocks execution of goroutine until an order appears
-func (w *Waiter) Wait() *entities.Order {
-	order := <-w.results
-	return order
+// Wait blocks execution of goroutine until an order appears or the context is done
+// Returns error of the context if the order did not appear in time
+func (w *Waiter) Wait(ctx context.Context) (*entities.Order, error) {
+	select {
+	case order := <-w.results:
+		return order, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
@@ -2,9 +2,11 @@ package orders
 
 import (
 	"bth-trader/internal/entities"
+	"context"
 	"github.com/ltunc/go-observer/observer"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestWaiter_Wait(t *testing.T) {
@@ -17,6 +19,7 @@ func TestWaiter_Wait(t *testing.T) {
 		fields        fields
 		notifications []*entities.Order
 		want          *entities.Order
+		wantErr       error
 	}{
 		{
 			name: "basic",
@@ -42,6 +45,23 @@ func TestWaiter_Wait(t *testing.T) {
 			notifications: []*entities.Order{{RefId: 5, OrderId: "test 5"}, {RefId: 6, OrderId: "test 6"}, {RefId: 7, OrderId: "test 7"}},
 			want:          &entities.Order{RefId: 6, OrderId: "test 6"},
 		},
+		{
+			name: "pending skipped",
+			fields: fields{
+				expectRefId: 9,
+			},
+			notifications: []*entities.Order{{RefId: 9, Status: "pending"}, {RefId: 9, OrderId: "test 9", Status: "open"}},
+			want:          &entities.Order{RefId: 9, OrderId: "test 9", Status: "open"},
+		},
+		{
+			name: "timeout",
+			fields: fields{
+				expectRefId: 8,
+			},
+			notifications: []*entities.Order{{RefId: 5, OrderId: "test 5"}},
+			want:          nil,
+			wantErr:       context.DeadlineExceeded,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -49,9 +69,15 @@ func TestWaiter_Wait(t *testing.T) {
 			for _, n := range tt.notifications {
 				w.Notify(n)
 			}
-			if got := w.Wait(); !reflect.DeepEqual(got, tt.want) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+			defer cancel()
+			got, err := w.Wait(ctx)
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Wait() = %v, want %v", got, tt.want)
 			}
+			if err != tt.wantErr {
+				t.Errorf("Wait() error = %v, want %v", err, tt.wantErr)
+			}
 		})
 	}
 }
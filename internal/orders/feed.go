@@ -0,0 +1,129 @@
+package orders
+
+import (
+	"bth-trader/internal/entities"
+	"errors"
+	"sync"
+)
+
+// DefaultFeedSize is number of events kept by the feed for resuming streams
+const DefaultFeedSize = 10000
+
+// ErrEvicted is returned when requested events were already removed from the feed
+var ErrEvicted = errors.New("events are no longer available")
+
+// ErrUnknownSeq is returned when the requested sequence number was not published yet,
+// e.g. it comes from the feed before a restart
+var ErrUnknownSeq = errors.New("sequence number is ahead of the feed")
+
+// Event is an update of an order numbered in order of appearance
+type Event struct {
+	Seq   uint64
+	Order *entities.Order
+}
+
+// Feed is an observer which numbers updates of orders and keeps the latest of them in a bounded buffer,
+// so readers can follow updates at their own pace and resume after reconnecting.
+// Events contain the state of orders merged in the storage, so the feed should be subscribed after the storage.
+type Feed struct {
+	events []Event
+	// first is the index of the oldest event in the ring buffer
+	first int
+	size  int
+	seq   uint64
+	// lookup returns the merged state of the order
+	lookup func(refId int) (*entities.Order, bool)
+	// last contains the latest published state of orders to skip updates ignored by the storage
+	last map[int]*entities.Order
+	// appended is closed when a new event is added
+	appended chan struct{}
+	mu       *sync.Mutex
+}
+
+// NewFeed creates a feed which keeps size latest events
+func NewFeed(size int, lookup func(refId int) (*entities.Order, bool)) *Feed {
+	if size <= 0 {
+		size = DefaultFeedSize
+	}
+	return &Feed{
+		size:     size,
+		lookup:   lookup,
+		last:     make(map[int]*entities.Order),
+		appended: make(chan struct{}),
+		mu:       &sync.Mutex{},
+	}
+}
+
+// Notify adds the update of the order to the feed
+func (f *Feed) Notify(o *entities.Order) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lookup != nil && o.RefId != 0 {
+		if stored, ok := f.lookup(o.RefId); ok {
+			if f.last[o.RefId] == stored {
+				// the storage ignored the update, nothing changed
+				return
+			}
+			o = stored
+		}
+	}
+	f.seq++
+	ev := Event{Seq: f.seq, Order: o}
+	if len(f.events) < f.size {
+		f.events = append(f.events, ev)
+	} else {
+		// the latest state is kept while the storage still has it, otherwise updates ignored by the storage
+		// after the eviction would be published again
+		evicted := f.events[f.first].Order
+		if f.last[evicted.RefId] == evicted {
+			if stored, ok := f.lookupOrder(evicted.RefId); !ok || stored != evicted {
+				delete(f.last, evicted.RefId)
+			}
+		}
+		f.events[f.first] = ev
+		f.first = (f.first + 1) % f.size
+	}
+	if o.RefId != 0 {
+		f.last[o.RefId] = o
+	}
+	close(f.appended)
+	f.appended = make(chan struct{})
+}
+
+func (f *Feed) lookupOrder(refId int) (*entities.Order, bool) {
+	if f.lookup == nil {
+		return nil, false
+	}
+	return f.lookup(refId)
+}
+
+// Seq returns the sequence number of the latest event
+func (f *Feed) Seq() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.seq
+}
+
+// Since returns events after the sequence number and a channel which is closed when next event is added
+// Returns ErrEvicted if some events after seq were already removed from the buffer
+// and ErrUnknownSeq if seq is greater than the sequence number of the latest event.
+func (f *Feed) Since(seq uint64) ([]Event, <-chan struct{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if seq > f.seq {
+		return nil, nil, ErrUnknownSeq
+	}
+	if seq == f.seq {
+		return nil, f.appended, nil
+	}
+	oldest := f.seq - uint64(len(f.events)) + 1
+	if seq+1 < oldest {
+		return nil, nil, ErrEvicted
+	}
+	n := int(f.seq - seq)
+	result := make([]Event, 0, n)
+	for i := len(f.events) - n; i < len(f.events); i++ {
+		result = append(result, f.events[(f.first+i)%len(f.events)])
+	}
+	return result, f.appended, nil
+}
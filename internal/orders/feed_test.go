@@ -0,0 +1,77 @@
+package orders
+
+import (
+	"bth-trader/internal/entities"
+	"errors"
+	"testing"
+)
+
+func TestFeed_Since(t *testing.T) {
+	f := NewFeed(3, nil)
+	if events, wait, err := f.Since(0); err != nil || len(events) != 0 || wait == nil {
+		t.Fatalf("Since() on empty feed = %v, %v, %v", events, wait, err)
+	}
+	_, wait, _ := f.Since(0)
+	for i := 1; i <= 5; i++ {
+		f.Notify(&entities.Order{RefId: 1000 + i, Status: "open"})
+	}
+	select {
+	case <-wait:
+	default:
+		t.Errorf("Since() channel is not closed after new events")
+	}
+	events, _, err := f.Since(3)
+	if err != nil {
+		t.Fatalf("Since() error = %v", err)
+	}
+	if len(events) != 2 || events[0].Seq != 4 || events[1].Seq != 5 || events[1].Order.RefId != 1005 {
+		t.Errorf("Since(3) got %v", events)
+	}
+	if events, _, _ := f.Since(2); len(events) != 3 || events[0].Seq != 3 {
+		t.Errorf("Since(2) got %v", events)
+	}
+	if _, _, err := f.Since(1); !errors.Is(err, ErrEvicted) {
+		t.Errorf("Since(1) error = %v, want %v", err, ErrEvicted)
+	}
+	if _, _, err := f.Since(6); !errors.Is(err, ErrUnknownSeq) {
+		t.Errorf("Since(6) error = %v, want %v", err, ErrUnknownSeq)
+	}
+	if f.Seq() != 5 {
+		t.Errorf("Seq() = %d, want 5", f.Seq())
+	}
+}
+
+func TestFeed_Merged(t *testing.T) {
+	s := NewStorage()
+	f := NewFeed(10, s.Find)
+	d := NewDispatcher()
+	d.Subscribe(s)
+	d.Subscribe(f)
+	d.Fire(&entities.Order{OrderId: "ABC1101", RefId: 1101, Pair: "XBT/EUR", Status: "open"})
+	d.Fire(&entities.Order{OrderId: "ABC1101", RefId: 1101, Status: "closed"})
+	// regression is ignored by the storage and not published
+	d.Fire(&entities.Order{OrderId: "ABC1101", RefId: 1101, Status: "open"})
+	events, _, _ := f.Since(0)
+	if len(events) != 2 {
+		t.Fatalf("Since() got %d events, want 2", len(events))
+	}
+	if o := events[1].Order; o.Status != "closed" || o.Pair != "XBT/EUR" {
+		t.Errorf("Since() got %v, want merged closed order", o)
+	}
+}
+
+func TestFeed_EvictedNotRepublished(t *testing.T) {
+	s := NewStorage()
+	f := NewFeed(2, s.Find)
+	d := NewDispatcher()
+	d.Subscribe(s)
+	d.Subscribe(f)
+	d.Fire(&entities.Order{OrderId: "ABC1101", RefId: 1101, Pair: "XBT/EUR", Status: "closed"})
+	// the event of the closed order is evicted by updates of other orders
+	d.Fire(&entities.Order{OrderId: "ABC1102", RefId: 1102, Status: "open"})
+	d.Fire(&entities.Order{OrderId: "ABC1103", RefId: 1103, Status: "open"})
+	d.Fire(&entities.Order{OrderId: "ABC1101", RefId: 1101, Status: "open"})
+	if f.Seq() != 3 {
+		t.Errorf("Seq() = %d, want 3, the ignored update should not be published", f.Seq())
+	}
+}
@@ -0,0 +1,90 @@
+package orders
+
+import (
+	"bth-trader/internal/entities"
+	"errors"
+	"time"
+)
+
+// ErrKeyReused is returned when an idempotency key is used again for a different order
+var ErrKeyReused = errors.New("idempotency key is used for another order")
+
+// idempotencyKey binds a key set by the client to the order placed with it
+type idempotencyKey struct {
+	RefId int
+	// Fingerprint identifies parameters of the order, the key cannot be reused with other parameters
+	Fingerprint string
+	Expires     time.Time
+	// Order is the last known state of the order, it is kept after the order is removed from the storage
+	Order *entities.Order `json:",omitempty"`
+}
+
+// Claim binds the key to the order with refId for ttl
+// If the key is already bound to an order, returns refId of that order and false.
+// Returns ErrKeyReused if the key is bound to an order with another fingerprint.
+func (s *Storage) Claim(key, fingerprint string, refId int, ttl time.Duration) (int, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k, fresh, err := s.claim(key, fingerprint, refId, ttl, time.Now())
+	return k.RefId, fresh, err
+}
+
+func (s *Storage) claim(key, fingerprint string, refId int, ttl time.Duration, now time.Time) (*idempotencyKey, bool, error) {
+	if k, ok := s.keys[key]; ok && now.Before(k.Expires) {
+		if k.Fingerprint != fingerprint {
+			return k, false, ErrKeyReused
+		}
+		return k, false, nil
+	}
+	k := &idempotencyKey{RefId: refId, Fingerprint: fingerprint, Expires: now.Add(ttl)}
+	s.putKey(key, k)
+	return k, true, nil
+}
+
+func (s *Storage) putKey(key string, k *idempotencyKey) {
+	if s.keys == nil {
+		s.keys = make(map[string]*idempotencyKey)
+	}
+	if order, ok := s.buffer[k.RefId]; ok && order.IdempotencyKey == key {
+		k.Order = order
+	}
+	s.keys[key] = k
+}
+
+// Release removes the key, e.g. if the order was not sent to the exchange
+func (s *Storage) Release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
+}
+
+// ByIdempotencyKey returns the last known state of the order placed with the key
+// The order is returned while the key is retained, even if the order was removed from the storage.
+func (s *Storage) ByIdempotencyKey(key string) (*entities.Order, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k, ok := s.keys[key]
+	if !ok || !time.Now().Before(k.Expires) || k.Order == nil {
+		return nil, false
+	}
+	return k.Order, true
+}
+
+// trackKey updates the state of the order bound to its idempotency key
+func (s *Storage) trackKey(order *entities.Order) {
+	if order.IdempotencyKey == "" {
+		return
+	}
+	if k, ok := s.keys[order.IdempotencyKey]; ok && k.RefId == order.RefId {
+		k.Order = order
+	}
+}
+
+// expireKeys removes keys retained longer than their ttl
+func (s *Storage) expireKeys(now time.Time) {
+	for key, k := range s.keys {
+		if !now.Before(k.Expires) {
+			delete(s.keys, key)
+		}
+	}
+}
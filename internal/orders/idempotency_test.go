@@ -0,0 +1,70 @@
+package orders
+
+import (
+	"bth-trader/internal/entities"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStorage_Claim(t *testing.T) {
+	s := NewStorage()
+	now := time.Now()
+	if k, fresh, err := s.claim("key-1", "fp", 801, time.Minute, now); err != nil || !fresh || k.RefId != 801 {
+		t.Fatalf("claim() = %v, %v, %v; want fresh claim of 801", k, fresh, err)
+	}
+	if k, fresh, err := s.claim("key-1", "fp", 802, time.Minute, now.Add(time.Second)); err != nil || fresh || k.RefId != 801 {
+		t.Errorf("repeated claim() = %v, %v, %v; want existing claim of 801", k, fresh, err)
+	}
+	if _, _, err := s.claim("key-1", "other", 803, time.Minute, now.Add(time.Second)); !errors.Is(err, ErrKeyReused) {
+		t.Errorf("claim() with another fingerprint error = %v, want %v", err, ErrKeyReused)
+	}
+	if k, fresh, _ := s.claim("key-1", "other", 804, time.Minute, now.Add(time.Minute)); !fresh || k.RefId != 804 {
+		t.Errorf("claim() of expired key = %v, %v; want fresh claim of 804", k, fresh)
+	}
+}
+
+func TestStorage_ByIdempotencyKey(t *testing.T) {
+	s := NewStorage()
+	if _, _, err := s.Claim("key-2", "fp", 811, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	_ = s.Add(&entities.Order{RefId: 811, IdempotencyKey: "key-2", Status: "pending"})
+	_ = s.Add(&entities.Order{OrderId: "ABC811", RefId: 811, Status: "open"})
+	s.Remove(811)
+	got, ok := s.ByIdempotencyKey("key-2")
+	if !ok || got.OrderId != "ABC811" || got.Status != "open" {
+		t.Errorf("ByIdempotencyKey() = %v, %v; want open order ABC811", got, ok)
+	}
+	s.Release("key-2")
+	if _, ok := s.ByIdempotencyKey("key-2"); ok {
+		t.Errorf("ByIdempotencyKey() found released key")
+	}
+}
+
+func TestFileStorage_Claim(t *testing.T) {
+	dir := t.TempDir()
+	f, err := OpenFileStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := f.Claim("key-3", "fp", 821, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Add(&entities.Order{RefId: 821, IdempotencyKey: "key-3", Status: "pending"})
+	_ = f.Add(&entities.Order{OrderId: "ABC821", RefId: 821, Status: "open"})
+	_ = f.Close()
+
+	reopened, err := OpenFileStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	refId, fresh, err := reopened.Claim("key-3", "fp", 822, time.Hour)
+	if err != nil || fresh || refId != 821 {
+		t.Errorf("Claim() after restart = %v, %v, %v; want existing claim of 821", refId, fresh, err)
+	}
+	if got, ok := reopened.ByIdempotencyKey("key-3"); !ok || got.OrderId != "ABC821" {
+		t.Errorf("ByIdempotencyKey() after restart = %v, %v", got, ok)
+	}
+}
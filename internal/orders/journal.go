@@ -0,0 +1,399 @@
+package orders
+
+import (
+	"bth-trader/internal/entities"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	journalFile  = "orders.journal"
+	snapshotFile = "orders.snapshot"
+	// compactEvery is number of records in the journal after which it is compacted into a snapshot
+	compactEvery = 1000
+)
+
+// operations recorded in the journal
+const (
+	opPut     = "put"
+	opRemove  = "remove"
+	opClaim   = "claim"
+	opRelease = "release"
+)
+
+// record is a single change of the storage written to the journal
+type record struct {
+	Seq        uint64
+	Op         string
+	RefId      int
+	Order      *entities.Order `json:",omitempty"`
+	Transition *Transition     `json:",omitempty"`
+	// Time is time of the change
+	Time time.Time `json:",omitempty"`
+	// Key and Claim describe an idempotency key for claim and release operations
+	Key   string          `json:",omitempty"`
+	Claim *idempotencyKey `json:",omitempty"`
+}
+
+// snapshot is the state of the storage at the moment when the journal was compacted
+type snapshot struct {
+	// Seq is sequence number of the last record included in the snapshot
+	Seq     uint64
+	Orders  []*entities.Order
+	History map[int][]Transition
+	Keys    map[string]*idempotencyKey
+	Times   map[int]orderTimes
+}
+
+// FileStorage is a Storage which writes every change to an append-only journal on disk,
+// so orders survive restarts of the trader.
+// The journal is compacted into a snapshot periodically, on start the snapshot is loaded and the journal is replayed.
+type FileStorage struct {
+	*Storage
+	dir     string
+	journal *os.File
+	seq     uint64
+	// records is number of records written to the journal since the last compaction
+	records int
+	// mu keeps records in the journal in the same order as changes applied to the storage
+	mu *sync.Mutex
+}
+
+// OpenFileStorage opens a storage in the directory, the directory is created if it does not exist
+// Orders from the previous run are restored from the snapshot and the journal.
+func OpenFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("cannot create storage directory: %w", err)
+	}
+	f := &FileStorage{Storage: NewStorage(), dir: dir, mu: &sync.Mutex{}}
+	if err := f.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	if err := f.replay(); err != nil {
+		return nil, err
+	}
+	// compaction rewrites the journal, so a broken tail after a crash is dropped
+	if err := f.Compact(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Notify notifies the storage about new order or an update for it
+func (f *FileStorage) Notify(order *entities.Order) {
+	if err := f.Add(order); err != nil {
+		log.Printf("update of order %d is rejected: %v", order.RefId, err)
+	}
+}
+
+// Add adds an order to the storage and writes the result to the journal
+func (f *FileStorage) Add(order *entities.Order) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored, tr, err := f.Storage.apply(order)
+	if err != nil || stored == nil {
+		return err
+	}
+	defer f.Storage.fire(stored)
+	return f.write(record{Op: opPut, RefId: stored.RefId, Order: stored, Transition: tr, Time: time.Now()})
+}
+
+// Insert adds a new order if its client order id is not used by another order and writes it to the journal
+func (f *FileStorage) Insert(order *entities.Order) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored, tr, err := f.Storage.insert(order)
+	if err != nil || stored == nil {
+		return err
+	}
+	defer f.Storage.fire(stored)
+	return f.write(record{Op: opPut, RefId: stored.RefId, Order: stored, Transition: tr, Time: time.Now()})
+}
+
+// Remove removes an order from the storage
+func (f *FileStorage) Remove(refId int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Storage.mu.Lock()
+	removed := f.Storage.remove(refId)
+	f.Storage.mu.Unlock()
+	if !removed {
+		return
+	}
+	if err := f.write(record{Op: opRemove, RefId: refId}); err != nil {
+		log.Printf("cannot write removal of order %d: %v", refId, err)
+	}
+}
+
+// Cleanup removes finished orders from the storage after cancelTtl
+func (f *FileStorage) Cleanup() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, refId := range f.Storage.cleanup() {
+		if err := f.write(record{Op: opRemove, RefId: refId}); err != nil {
+			log.Printf("cannot write removal of order %d: %v", refId, err)
+		}
+	}
+}
+
+// Claim binds the key to the order with refId for ttl and writes the key to the journal
+func (f *FileStorage) Claim(key, fingerprint string, refId int, ttl time.Duration) (int, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Storage.mu.Lock()
+	k, fresh, err := f.Storage.claim(key, fingerprint, refId, ttl, time.Now())
+	claimed := *k
+	f.Storage.mu.Unlock()
+	if !fresh {
+		return claimed.RefId, false, err
+	}
+	claimed.Order = nil
+	if err := f.write(record{Op: opClaim, RefId: refId, Key: key, Claim: &claimed}); err != nil {
+		return refId, true, err
+	}
+	return refId, true, nil
+}
+
+// Release removes the key and writes the removal to the journal
+func (f *FileStorage) Release(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Storage.Release(key)
+	if err := f.write(record{Op: opRelease, Key: key}); err != nil {
+		log.Printf("cannot write release of idempotency key: %v", err)
+	}
+}
+
+// Close closes the journal
+func (f *FileStorage) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.journal == nil {
+		return nil
+	}
+	err := f.journal.Close()
+	f.journal = nil
+	return err
+}
+
+// Compact writes the current state of the storage to the snapshot and truncates the journal
+func (f *FileStorage) Compact() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.compact()
+}
+
+func (f *FileStorage) compact() error {
+	snap := snapshot{
+		Seq:     f.seq,
+		History: make(map[int][]Transition),
+		Keys:    make(map[string]*idempotencyKey),
+		Times:   make(map[int]orderTimes),
+	}
+	f.Storage.mu.Lock()
+	for refId, order := range f.Storage.buffer {
+		snap.Orders = append(snap.Orders, order)
+		snap.History[refId] = append([]Transition(nil), f.Storage.history[refId]...)
+		snap.Times[refId] = f.Storage.times[refId]
+	}
+	for key, k := range f.Storage.keys {
+		copied := *k
+		snap.Keys[key] = &copied
+	}
+	f.Storage.mu.Unlock()
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("cannot encode snapshot: %w", err)
+	}
+	if err := writeFile(filepath.Join(f.dir, snapshotFile), data); err != nil {
+		return fmt.Errorf("cannot write snapshot: %w", err)
+	}
+	// the snapshot is synced to disk with its directory before the journal is truncated
+	// records of the journal are already in the snapshot, they are skipped on replay by their sequence numbers
+	// so a crash between writing the snapshot and truncating the journal does not duplicate changes
+	if f.journal != nil {
+		_ = f.journal.Close()
+	}
+	f.journal, err = os.OpenFile(filepath.Join(f.dir, journalFile), os.O_CREATE|os.O_WRONLY|os.O_TRUNC|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot open journal: %w", err)
+	}
+	f.records = 0
+	return nil
+}
+
+// write appends the record to the journal, the journal is compacted when it becomes too long
+func (f *FileStorage) write(r record) error {
+	if f.journal == nil {
+		return errors.New("journal is closed")
+	}
+	f.seq++
+	r.Seq = f.seq
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("cannot encode record: %w", err)
+	}
+	if _, err := f.journal.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("cannot write to journal: %w", err)
+	}
+	if err := f.journal.Sync(); err != nil {
+		return fmt.Errorf("cannot sync journal: %w", err)
+	}
+	f.records++
+	if f.records >= compactEvery {
+		return f.compact()
+	}
+	return nil
+}
+
+// loadSnapshot restores the storage from the snapshot if it exists
+func (f *FileStorage) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(f.dir, snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read snapshot: %w", err)
+	}
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("cannot decode snapshot: %w", err)
+	}
+	f.seq = snap.Seq
+	for _, order := range snap.Orders {
+		f.restore(order)
+	}
+	for refId, history := range snap.History {
+		if _, ok := f.Storage.buffer[refId]; ok {
+			f.Storage.history[refId] = history
+		}
+	}
+	for refId, t := range snap.Times {
+		if _, ok := f.Storage.buffer[refId]; ok {
+			f.Storage.times[refId] = t
+		}
+	}
+	now := time.Now()
+	for key, k := range snap.Keys {
+		if now.Before(k.Expires) {
+			f.Storage.putKey(key, k)
+		}
+	}
+	return nil
+}
+
+// replay applies records of the journal which are not in the snapshot yet
+// A broken record can only be the last one, written partially during a crash, it is ignored with the rest of the journal.
+func (f *FileStorage) replay() error {
+	file, err := os.Open(filepath.Join(f.dir, journalFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot open journal: %w", err)
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 {
+				log.Printf("journal ends with incomplete record, ignore it")
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("cannot read journal: %w", err)
+		}
+		var r record
+		if err := json.Unmarshal(line, &r); err != nil {
+			log.Printf("broken record in journal, ignore the rest of it: %v", err)
+			return nil
+		}
+		if r.Seq <= f.seq {
+			continue
+		}
+		f.seq = r.Seq
+		switch r.Op {
+		case opPut:
+			if r.Order == nil {
+				continue
+			}
+			_, known := f.Storage.buffer[r.RefId]
+			created := !known || (r.Transition != nil && r.Transition.From == "")
+			f.restore(r.Order)
+			if created {
+				// a new order, refId could be used by another order before
+				delete(f.Storage.history, r.RefId)
+			}
+			if !r.Time.IsZero() {
+				f.Storage.touch(r.RefId, r.Time, created)
+			}
+			if r.Transition != nil {
+				f.Storage.addTransition(r.RefId, *r.Transition)
+			}
+		case opRemove:
+			f.Storage.remove(r.RefId)
+		case opClaim:
+			if r.Claim != nil && time.Now().Before(r.Claim.Expires) {
+				f.Storage.putKey(r.Key, r.Claim)
+			}
+		case opRelease:
+			delete(f.Storage.keys, r.Key)
+		}
+	}
+}
+
+// restore puts the order to the storage as it is, without merging
+func (f *FileStorage) restore(order *entities.Order) {
+	f.Storage.put(order)
+	if order.ReplacedId != "" {
+		f.Storage.replaced[order.ReplacedId] = true
+	}
+}
+
+// writeFile writes data to a temporary file and renames it, so the file is never written partially
+// The directory is synced after renaming, so the new file survives a crash.
+func writeFile(name string, data []byte) error {
+	tmp := name + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, name); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(name))
+}
+
+// syncDir flushes entries of the directory to disk, e.g. after renaming a file in it
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := d.Sync(); err != nil {
+		_ = d.Close()
+		return err
+	}
+	return d.Close()
+}
@@ -0,0 +1,104 @@
+package orders
+
+import (
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/entities"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFileStorage_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	f, err := OpenFileStorage(dir)
+	if err != nil {
+		t.Fatalf("OpenFileStorage() error = %v", err)
+	}
+	updates := []*entities.Order{
+		{RefId: 301, Pair: "XBT/EUR", Status: "pending"},
+		{OrderId: "ABC301", RefId: 301, Status: "open"},
+		{OrderId: "ABC301", RefId: 301, VolumeExec: decimal.MustParse("0.5")},
+		{RefId: 302, Status: "pending"},
+		{OrderId: "ABC303", RefId: 303, Status: "open"},
+	}
+	for _, u := range updates {
+		if err := f.Add(u); err != nil {
+			t.Fatalf("Add(%v) error = %v", u, err)
+		}
+	}
+	f.Remove(302)
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	reopened, err := OpenFileStorage(dir)
+	if err != nil {
+		t.Fatalf("OpenFileStorage() error = %v", err)
+	}
+	defer reopened.Close()
+	want := map[int]*entities.Order{
+		301: {OrderId: "ABC301", RefId: 301, Pair: "XBT/EUR", Status: "open", VolumeExec: decimal.MustParse("0.5")},
+		303: {OrderId: "ABC303", RefId: 303, Status: "open"},
+	}
+	if got := reopened.buffer; !reflect.DeepEqual(got, want) {
+		t.Errorf("restored buffer %v, want %v", got, want)
+	}
+	history, _ := reopened.History(301)
+	if len(history) != 2 || history[0].To != "pending" || history[1].To != "open" {
+		t.Errorf("restored history %v", history)
+	}
+	// the restored storage keeps enforcing the lifecycle
+	if err := reopened.Add(&entities.Order{OrderId: "ABC301", RefId: 301, Status: "pending"}); err == nil {
+		t.Errorf("Add() accepted regression after restore")
+	}
+}
+
+func TestFileStorage_BrokenTail(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	dir := t.TempDir()
+	journal := `{"Seq":1,"Op":"put","RefId":401,"Order":{"OrderId":"ABC401","RefId":401,"Status":"open"}}
+{"Seq":2,"Op":"put","RefId":402,"Order":{"OrderId":"ABC402","RefId":402,"Sta`
+	if err := os.WriteFile(filepath.Join(dir, journalFile), []byte(journal), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := OpenFileStorage(dir)
+	if err != nil {
+		t.Fatalf("OpenFileStorage() error = %v", err)
+	}
+	defer f.Close()
+	if _, ok := f.Find(401); !ok {
+		t.Errorf("complete record is not restored")
+	}
+	if _, ok := f.Find(402); ok {
+		t.Errorf("incomplete record is restored")
+	}
+}
+
+func TestFileStorage_Compact(t *testing.T) {
+	dir := t.TempDir()
+	f, err := OpenFileStorage(dir)
+	if err != nil {
+		t.Fatalf("OpenFileStorage() error = %v", err)
+	}
+	for i := 1; i <= compactEvery+10; i++ {
+		if err := f.Add(&entities.Order{OrderId: "ABC500", RefId: 500 + i%3, Status: "open", VolumeExec: decimal.NewFromInt(int64(i))}); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	_ = f.Close()
+	if f.records != 10 {
+		t.Errorf("journal is not compacted, records = %d", f.records)
+	}
+	reopened, err := OpenFileStorage(dir)
+	if err != nil {
+		t.Fatalf("OpenFileStorage() error = %v", err)
+	}
+	defer reopened.Close()
+	if !reflect.DeepEqual(reopened.buffer, f.buffer) {
+		t.Errorf("restored buffer %v, want %v", reopened.buffer, f.buffer)
+	}
+}
@@ -0,0 +1,51 @@
+package orders
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrRegression is returned when an update tries to move an order back in its lifecycle
+var ErrRegression = errors.New("order status regression")
+
+// stages of the order lifecycle, an order can only move forward:
+// pending -> open -> closed/canceled/expired
+// error is a final stage for orders rejected by the exchange
+var stages = map[string]int{
+	"pending":  0,
+	"open":     1,
+	"opened":   1,
+	"closed":   2,
+	"canceled": 2,
+	"expired":  2,
+	"error":    2,
+}
+
+// Transition is a change of the order status
+type Transition struct {
+	// OrderId is id of the order on the exchange at the moment of transition, empty for pending orders
+	OrderId string
+	From    string
+	To      string
+	Time    time.Time
+}
+
+// checkTransition checks if the order can move from one status to another
+// unknown statuses are accepted, since they cannot be compared
+func checkTransition(from, to string) error {
+	if from == to {
+		return nil
+	}
+	fromStage, ok := stages[from]
+	if !ok {
+		return nil
+	}
+	toStage, ok := stages[to]
+	if !ok {
+		return nil
+	}
+	if toStage < fromStage || (toStage == fromStage && toStage == stages["closed"]) {
+		return ErrRegression
+	}
+	return nil
+}
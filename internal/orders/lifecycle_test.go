@@ -0,0 +1,33 @@
+package orders
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_checkTransition(t *testing.T) {
+	tests := []struct {
+		from    string
+		to      string
+		wantErr error
+	}{
+		{"", "pending", nil},
+		{"pending", "open", nil},
+		{"pending", "error", nil},
+		{"pending", "closed", nil},
+		{"open", "open", nil},
+		{"open", "canceled", nil},
+		{"open", "pending", ErrRegression},
+		{"closed", "open", ErrRegression},
+		{"canceled", "closed", ErrRegression},
+		{"expired", "expired", nil},
+		{"open", "unknown", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			if err := checkTransition(tt.from, tt.to); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkTransition() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package orders
+
+import (
+	"bth-trader/internal/entities"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	SortByCreated = "created"
+	SortByUpdated = "updated"
+	// DefaultPageSize is number of orders in a page if the limit is not set
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+// ErrInvalidQuery is returned for a query with unknown sorting or a broken cursor
+var ErrInvalidQuery = errors.New("invalid query")
+
+// orderTimes are times of creation and the last update of an order in the storage
+type orderTimes struct {
+	Created time.Time
+	Updated time.Time
+}
+
+// Query describes which orders to list and in which order
+type Query struct {
+	// Statuses limits orders to these statuses, all statuses are listed if empty
+	Statuses []string
+	Pair     string
+	Side     string
+	Tag      string
+	// From and To limit the time of creation or the last update according to SortBy, zero time is not limited
+	From time.Time
+	To   time.Time
+	// SortBy is SortByCreated (default) or SortByUpdated
+	SortBy     string
+	Descending bool
+	Limit      int
+	// Cursor is a cursor of the previous page, the listing continues after it
+	Cursor string
+}
+
+// Listed is an order with times of its creation and the last update in the storage
+type Listed struct {
+	Order   *entities.Order
+	Created time.Time
+	Updated time.Time
+}
+
+// sortKey returns the time the listing is sorted by
+func (l Listed) sortKey(sortBy string) time.Time {
+	if sortBy == SortByUpdated {
+		return l.Updated
+	}
+	return l.Created
+}
+
+// List returns a page of orders matching the query and the cursor of the next page
+// The cursor is empty if there are no more orders.
+func (s *Storage) List(q Query) ([]Listed, string, error) {
+	switch q.SortBy {
+	case "":
+		q.SortBy = SortByCreated
+	case SortByCreated, SortByUpdated:
+	default:
+		return nil, "", fmt.Errorf("%w: unknown sorting %q", ErrInvalidQuery, q.SortBy)
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultPageSize
+	}
+	if q.Limit > MaxPageSize {
+		q.Limit = MaxPageSize
+	}
+	var after *cursor
+	if q.Cursor != "" {
+		c, err := parseCursor(q.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		after = &c
+	}
+	statuses := make(map[string]bool, len(q.Statuses))
+	for _, st := range q.Statuses {
+		statuses[st] = true
+	}
+	s.mu.Lock()
+	var found []Listed
+	for refId, order := range s.buffer {
+		t := s.times[refId]
+		l := Listed{Order: order, Created: t.Created, Updated: t.Updated}
+		if q.matches(l, statuses) {
+			found = append(found, l)
+		}
+	}
+	s.mu.Unlock()
+	less := func(a, b Listed) bool {
+		ka, kb := a.sortKey(q.SortBy), b.sortKey(q.SortBy)
+		if !ka.Equal(kb) {
+			return ka.Before(kb) != q.Descending
+		}
+		if a.Order.RefId == b.Order.RefId {
+			return false
+		}
+		return (a.Order.RefId < b.Order.RefId) != q.Descending
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return less(found[i], found[j])
+	})
+	if after != nil {
+		// orders are skipped up to the last order of the previous page
+		last := Listed{Order: &entities.Order{RefId: after.refId}, Created: after.key, Updated: after.key}
+		start := sort.Search(len(found), func(i int) bool {
+			return less(last, found[i])
+		})
+		found = found[start:]
+	}
+	if len(found) <= q.Limit {
+		return found, "", nil
+	}
+	page := found[:q.Limit]
+	last := page[len(page)-1]
+	return page, cursor{key: last.sortKey(q.SortBy), refId: last.Order.RefId}.String(), nil
+}
+
+func (q Query) matches(l Listed, statuses map[string]bool) bool {
+	o := l.Order
+	if len(statuses) > 0 && !statuses[o.Status] {
+		return false
+	}
+	if (q.Pair != "" && o.Pair != q.Pair) || (q.Side != "" && o.Side != q.Side) || (q.Tag != "" && o.Tag != q.Tag) {
+		return false
+	}
+	key := l.sortKey(q.SortBy)
+	if !q.From.IsZero() && key.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !key.Before(q.To) {
+		return false
+	}
+	return true
+}
+
+// cursor points to the last order of a page
+type cursor struct {
+	key   time.Time
+	refId int
+}
+
+func (c cursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", c.key.UnixNano(), c.refId)))
+}
+
+func parseCursor(s string) (cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, fmt.Errorf("%w: broken cursor", ErrInvalidQuery)
+	}
+	var nanos int64
+	var c cursor
+	if _, err := fmt.Sscanf(string(data), "%d:%d", &nanos, &c.refId); err != nil {
+		return cursor{}, fmt.Errorf("%w: broken cursor", ErrInvalidQuery)
+	}
+	c.key = time.Unix(0, nanos)
+	return c, nil
+}
+
+// touch updates times of the order, the time of creation is set for new orders
+func (s *Storage) touch(refId int, at time.Time, created bool) {
+	if s.times == nil {
+		s.times = make(map[int]orderTimes)
+	}
+	t, ok := s.times[refId]
+	if !ok || created {
+		t.Created = at
+	}
+	t.Updated = at
+	s.times[refId] = t
+}
@@ -0,0 +1,108 @@
+package orders
+
+import (
+	"bth-trader/internal/entities"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStorage_List(t *testing.T) {
+	s := NewStorage()
+	base := time.Unix(1660000000, 0)
+	orders := []*entities.Order{
+		{OrderId: "ABC901", RefId: 901, Pair: "XBT/EUR", Side: "buy", Status: "open", Tag: "grid"},
+		{OrderId: "ABC902", RefId: 902, Pair: "ETH/EUR", Side: "sell", Status: "open"},
+		{OrderId: "ABC903", RefId: 903, Pair: "XBT/EUR", Side: "sell", Status: "closed", Tag: "grid"},
+		{OrderId: "ABC904", RefId: 904, Pair: "XBT/EUR", Side: "buy", Status: "canceled"},
+		{OrderId: "ABC905", RefId: 905, Pair: "XBT/EUR", Side: "buy", Status: "open", Tag: "grid"},
+	}
+	for i, o := range orders {
+		_ = s.Add(o)
+		// orders are created one per minute and updated in the reverse order
+		s.times[o.RefId] = orderTimes{
+			Created: base.Add(time.Duration(i) * time.Minute),
+			Updated: base.Add(time.Hour - time.Duration(i)*time.Minute),
+		}
+	}
+	refIds := func(listed []Listed) []int {
+		var result []int
+		for _, l := range listed {
+			result = append(result, l.Order.RefId)
+		}
+		return result
+	}
+	tests := []struct {
+		name  string
+		query Query
+		want  []int
+	}{
+		{"all", Query{}, []int{901, 902, 903, 904, 905}},
+		{"statuses", Query{Statuses: []string{"open", "closed"}}, []int{901, 902, 903, 905}},
+		{"pair and side", Query{Pair: "XBT/EUR", Side: "buy"}, []int{901, 904, 905}},
+		{"tag", Query{Tag: "grid"}, []int{901, 903, 905}},
+		{"time range", Query{From: base.Add(time.Minute), To: base.Add(3 * time.Minute)}, []int{902, 903}},
+		{"by update", Query{SortBy: SortByUpdated}, []int{905, 904, 903, 902, 901}},
+		{"descending", Query{Descending: true}, []int{905, 904, 903, 902, 901}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, next, err := s.List(tt.query)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if next != "" {
+				t.Errorf("List() next cursor = %q, want empty", next)
+			}
+			if g := refIds(got); !equalInts(g, tt.want) {
+				t.Errorf("List() got %v, want %v", g, tt.want)
+			}
+		})
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+		var pages [][]int
+		q := Query{Descending: true, Limit: 2}
+		for {
+			got, next, err := s.List(q)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			pages = append(pages, refIds(got))
+			if next == "" {
+				break
+			}
+			q.Cursor = next
+		}
+		want := [][]int{{905, 904}, {903, 902}, {901}}
+		if len(pages) != len(want) {
+			t.Fatalf("List() pages %v, want %v", pages, want)
+		}
+		for i := range want {
+			if !equalInts(pages[i], want[i]) {
+				t.Errorf("List() page #%d got %v, want %v", i, pages[i], want[i])
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		if _, _, err := s.List(Query{SortBy: "price"}); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("List() error = %v, want %v", err, ErrInvalidQuery)
+		}
+		if _, _, err := s.List(Query{Cursor: "%%%"}); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("List() error = %v, want %v", err, ErrInvalidQuery)
+		}
+	})
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
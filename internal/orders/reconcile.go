@@ -0,0 +1,152 @@
+package orders
+
+import (
+	"bth-trader/internal/entities"
+	"fmt"
+	"github.com/ltunc/go-observer/observer"
+	"log"
+	"sync"
+	"time"
+)
+
+// closedWindow is how far in the past closed orders are requested to find pending orders
+const closedWindow = time.Hour * 24
+
+// Exchange provides the exchange's view of the account's orders
+type Exchange interface {
+	OpenOrders() ([]*entities.Order, error)
+	ClosedOrders(start time.Time) ([]*entities.Order, error)
+	QueryOrders(orderIds ...string) ([]*entities.Order, error)
+}
+
+// Reconciler compares orders in the storage with orders on the exchange
+// and fires corrective updates through the dispatcher for orders changed while the trader did not see them,
+// e.g. when the connection was lost.
+type Reconciler struct {
+	exchange   Exchange
+	store      Store
+	dispatcher *observer.Subject[*entities.Order]
+	// PairName converts pair names of the exchange to names used by websocket API, e.g. XBTEUR to XBT/EUR
+	PairName func(name string) (string, bool)
+	// mu prevents concurrent reconciliations
+	mu *sync.Mutex
+}
+
+// NewReconciler creates a reconciler of the storage with the exchange
+func NewReconciler(exchange Exchange, store Store, dispatcher *observer.Subject[*entities.Order]) *Reconciler {
+	return &Reconciler{
+		exchange:   exchange,
+		store:      store,
+		dispatcher: dispatcher,
+		mu:         &sync.Mutex{},
+	}
+}
+
+// Reconcile fetches orders from the exchange, fires updates for orders which differ from the storage
+// returns the number of found discrepancies
+func (r *Reconciler) Reconcile() (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	open, err := r.exchange.OpenOrders()
+	if err != nil {
+		return 0, err
+	}
+	seen := make(map[string]bool, len(open))
+	fixed := 0
+	for _, o := range open {
+		seen[o.OrderId] = true
+		if o.RefId == 0 {
+			// the order was not placed by the trader
+			continue
+		}
+		if r.correct(o) {
+			fixed++
+		}
+	}
+	// orders active in the storage but not open on the exchange were finished while the trader did not see it
+	var missing []string
+	pending := make(map[int]bool)
+	for _, o := range r.store.Select(isActive) {
+		if seen[o.OrderId] {
+			continue
+		}
+		if o.OrderId == "" {
+			pending[o.RefId] = true
+		} else {
+			missing = append(missing, o.OrderId)
+		}
+	}
+	var finished []*entities.Order
+	if len(missing) > 0 {
+		found, err := r.exchange.QueryOrders(missing...)
+		if err != nil {
+			return fixed, fmt.Errorf("cannot query missing orders: %w", err)
+		}
+		finished = append(finished, found...)
+	}
+	if len(pending) > 0 {
+		// the confirmation of pending orders was lost, they can be found only by refId among closed orders
+		closed, err := r.exchange.ClosedOrders(time.Now().Add(-closedWindow))
+		if err != nil {
+			return fixed, fmt.Errorf("cannot get closed orders: %w", err)
+		}
+		for _, o := range closed {
+			if pending[o.RefId] {
+				finished = append(finished, o)
+			}
+		}
+	}
+	for _, o := range finished {
+		if r.correct(o) {
+			fixed++
+		}
+	}
+	return fixed, nil
+}
+
+// correct fires an update for the order if the storage has different state of it
+func (r *Reconciler) correct(actual *entities.Order) bool {
+	stored, ok := r.store.Find(actual.RefId)
+	if ok && stored.OrderId != "" && stored.OrderId != actual.OrderId {
+		// refId is used by another order, e.g. the order was replaced after editing
+		log.Printf("reconcile: order %s has refId %d of order %s, ignore", actual.OrderId, actual.RefId, stored.OrderId)
+		return false
+	}
+	if ok && !differs(stored, actual) {
+		return false
+	}
+	// pair names of REST API differ from websocket ones, the stored pair is kept unless the name is converted
+	if name, found := r.pairName(actual.Pair); found {
+		actual.Pair = name
+	} else if ok {
+		actual.Pair = ""
+	}
+	if ok {
+		log.Printf("reconcile: order %d (%s) is %s with executed volume %v, stored as %s with %v",
+			actual.RefId, actual.OrderId, actual.Status, actual.VolumeExec, stored.Status, stored.VolumeExec)
+	} else {
+		log.Printf("reconcile: unknown order %d (%s) is %s", actual.RefId, actual.OrderId, actual.Status)
+	}
+	r.dispatcher.Fire(actual)
+	return true
+}
+
+func (r *Reconciler) pairName(name string) (string, bool) {
+	if r.PairName == nil || name == "" {
+		return "", false
+	}
+	return r.PairName(name)
+}
+
+// differs checks if the exchange's state of the order differs from the stored one
+func differs(stored, actual *entities.Order) bool {
+	return stored.OrderId != actual.OrderId ||
+		stored.Status != actual.Status ||
+		stored.VolumeExec != actual.VolumeExec ||
+		stored.Cost != actual.Cost ||
+		stored.Fee != actual.Fee
+}
+
+func isActive(o *entities.Order) bool {
+	return o.Status == "pending" || o.Status == "open" || o.Status == "opened"
+}
@@ -0,0 +1,112 @@
+package orders
+
+import (
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/entities"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type mockExchange struct {
+	open    []*entities.Order
+	closed  []*entities.Order
+	queried []string
+}
+
+func (m *mockExchange) OpenOrders() ([]*entities.Order, error) {
+	return m.open, nil
+}
+
+func (m *mockExchange) ClosedOrders(_ time.Time) ([]*entities.Order, error) {
+	return m.closed, nil
+}
+
+func (m *mockExchange) QueryOrders(orderIds ...string) ([]*entities.Order, error) {
+	m.queried = append(m.queried, orderIds...)
+	var result []*entities.Order
+	for _, o := range m.closed {
+		for _, id := range orderIds {
+			if o.OrderId == id {
+				result = append(result, o)
+			}
+		}
+	}
+	return result, nil
+}
+
+func TestReconciler_Reconcile(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	store := NewStorage()
+	for _, o := range []*entities.Order{
+		{OrderId: "ABC601", RefId: 601, Pair: "XBT/EUR", Status: "open"},
+		{OrderId: "ABC602", RefId: 602, Pair: "XBT/EUR", Status: "open", VolumeExec: decimal.MustParse("1")},
+		{OrderId: "ABC603", RefId: 603, Pair: "XBT/EUR", Status: "open"},
+		{RefId: 604, Pair: "XBT/EUR", Status: "pending"},
+	} {
+		_ = store.Add(o)
+	}
+	exchange := &mockExchange{
+		open: []*entities.Order{
+			{OrderId: "ABC601", RefId: 601, Pair: "XBTEUR", Status: "open"},
+			{OrderId: "ABC602", RefId: 602, Pair: "XBTEUR", Status: "open", VolumeExec: decimal.MustParse("1.5")},
+			{OrderId: "ABC605", RefId: 605, Pair: "XBTEUR", Status: "open"},
+			{OrderId: "ABC606", Pair: "XBTEUR", Status: "open"},
+		},
+		closed: []*entities.Order{
+			{OrderId: "ABC603", RefId: 603, Pair: "XBTEUR", Status: "canceled"},
+			{OrderId: "ABC604", RefId: 604, Pair: "XBTEUR", Status: "closed", VolumeExec: decimal.MustParse("2")},
+		},
+	}
+	dispatcher := NewDispatcher()
+	dispatcher.Subscribe(store)
+	fixed, err := NewReconciler(exchange, store, dispatcher).Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if fixed != 4 {
+		t.Errorf("Reconcile() fixed = %d, want 4", fixed)
+	}
+	if !reflect.DeepEqual(exchange.queried, []string{"ABC603"}) {
+		t.Errorf("Reconcile() queried %v, want [ABC603]", exchange.queried)
+	}
+	want := map[int]*entities.Order{
+		601: {OrderId: "ABC601", RefId: 601, Pair: "XBT/EUR", Status: "open"},
+		602: {OrderId: "ABC602", RefId: 602, Pair: "XBT/EUR", Status: "open", VolumeExec: decimal.MustParse("1.5")},
+		603: {OrderId: "ABC603", RefId: 603, Pair: "XBT/EUR", Status: "canceled"},
+		604: {OrderId: "ABC604", RefId: 604, Pair: "XBT/EUR", Status: "closed", VolumeExec: decimal.MustParse("2")},
+		605: {OrderId: "ABC605", RefId: 605, Pair: "XBTEUR", Status: "open"},
+	}
+	if !reflect.DeepEqual(store.buffer, want) {
+		t.Errorf("Reconcile() storage %v, want %v", store.buffer, want)
+	}
+	// the second run finds nothing to correct
+	if fixed, _ := NewReconciler(exchange, store, dispatcher).Reconcile(); fixed != 0 {
+		t.Errorf("repeated Reconcile() fixed = %d, want 0", fixed)
+	}
+}
+
+func TestReconciler_PairName(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	store := NewStorage()
+	exchange := &mockExchange{
+		open: []*entities.Order{{OrderId: "ABC701", RefId: 701, Pair: "XBTEUR", Status: "open"}},
+	}
+	dispatcher := NewDispatcher()
+	dispatcher.Subscribe(store)
+	r := NewReconciler(exchange, store, dispatcher)
+	r.PairName = func(name string) (string, bool) {
+		return map[string]string{"XBTEUR": "XBT/EUR"}[name], name == "XBTEUR"
+	}
+	if _, err := r.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if o, ok := store.Find(701); !ok || o.Pair != "XBT/EUR" {
+		t.Errorf("Reconcile() stored %v, want pair XBT/EUR", o)
+	}
+}
@@ -0,0 +1,150 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// InstanceBits is number of high bits of refId used for the instance prefix
+	// refIds are positive int32, so 23 bits remain for the counter of each instance
+	InstanceBits = 8
+	MaxInstance  = 1<<InstanceBits - 1
+	counterBits  = 31 - InstanceBits
+	maxCounter   = 1<<counterBits - 1
+	// reserveBlock is number of refIds reserved with a single write of the high-water mark
+	reserveBlock = 1000
+)
+
+// ErrNoRefIds is returned when all refIds of the instance are used by stored orders
+var ErrNoRefIds = errors.New("no free refIds")
+
+// Allocator allocates unique refIds for orders
+// refIds consist of the instance prefix in high bits and a counter, so several instances do not collide.
+// The counter increases monotonically, its high-water mark is persisted in a file, so refIds are not reused
+// after restarts. Without the file the counter starts from the current time in seconds, so it is still ahead
+// of refIds of previous runs unless they allocated more than one refId per second.
+// When the counter is exhausted it starts again from 1 skipping refIds used by stored orders.
+type Allocator struct {
+	prefix int
+	next   int
+	// reserved is the high-water mark, counters below it can be allocated without writing the mark
+	reserved int
+	path     string
+	inUse    func(refId int) bool
+	mu       *sync.Mutex
+}
+
+// NewAllocator creates an allocator for the instance without persisting its state
+// inUse reports refIds which are already used by known orders
+func NewAllocator(instance int, inUse func(refId int) bool) (*Allocator, error) {
+	return OpenAllocator(instance, "", inUse)
+}
+
+// OpenAllocator creates an allocator which keeps its high-water mark in the file
+// Allocation continues after the mark from the previous run, or from the current time if there is no mark.
+func OpenAllocator(instance int, path string, inUse func(refId int) bool) (*Allocator, error) {
+	return openAllocator(instance, path, inUse, time.Now())
+}
+
+func openAllocator(instance int, path string, inUse func(refId int) bool, now time.Time) (*Allocator, error) {
+	if instance < 0 || instance > MaxInstance {
+		return nil, fmt.Errorf("instance should be from 0 to %d, got %d", MaxInstance, instance)
+	}
+	a := &Allocator{
+		prefix: instance << counterBits,
+		next:   int(now.Unix()%maxCounter) + 1,
+		path:   path,
+		inUse:  inUse,
+		mu:     &sync.Mutex{},
+	}
+	if path == "" {
+		a.reserved = maxCounter + 1
+		return a, nil
+	}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("cannot read refId mark: %w", err)
+	default:
+		mark, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse refId mark: %w", err)
+		}
+		if mark >= 1 && mark <= maxCounter {
+			a.next = mark
+		}
+	}
+	a.reserved = a.next
+	return a, nil
+}
+
+// Next returns a refId not used by any known order
+func (a *Allocator) Next() (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i := 0; i < maxCounter; i++ {
+		if a.next > maxCounter {
+			// the counter is exhausted, the oldest refIds are free again unless the orders are still stored
+			a.next = 1
+			a.reserved = 1
+		}
+		if a.next >= a.reserved {
+			if err := a.reserve(); err != nil {
+				return 0, err
+			}
+		}
+		refId := a.prefix | a.next
+		a.next++
+		if a.inUse == nil || !a.inUse(refId) {
+			return refId, nil
+		}
+	}
+	return 0, ErrNoRefIds
+}
+
+// Release returns the unused refId to the allocator, e.g. when the order was not sent
+// Only the latest allocated refId can be allocated again, other refIds are skipped.
+func (a *Allocator) Release(refId int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if refId&^maxCounter == a.prefix && refId&maxCounter == a.next-1 {
+		a.next--
+	}
+}
+
+// Advance moves the counter past refIds of the instance, e.g. userrefs of orders open on the exchange,
+// so they are not allocated again. The counter never moves back.
+func (a *Allocator) Advance(refIds []int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, refId := range refIds {
+		if refId&^maxCounter != a.prefix {
+			continue
+		}
+		if counter := refId & maxCounter; counter >= a.next && counter < maxCounter {
+			a.next = counter + 1
+		}
+	}
+}
+
+// reserve writes the high-water mark for the next block of refIds
+func (a *Allocator) reserve() error {
+	mark := a.next + reserveBlock
+	if mark > maxCounter+1 {
+		mark = maxCounter + 1
+	}
+	if a.path != "" {
+		if err := writeFile(a.path, []byte(strconv.Itoa(mark))); err != nil {
+			return fmt.Errorf("cannot write refId mark: %w", err)
+		}
+	}
+	a.reserved = mark
+	return nil
+}
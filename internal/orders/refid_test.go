@@ -0,0 +1,130 @@
+package orders
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAllocator_Next(t *testing.T) {
+	used := map[int]bool{3<<counterBits | 2: true}
+	// the counter starts from 1 at the unix epoch
+	a, err := openAllocator(3, "", func(refId int) bool { return used[refId] }, time.Unix(0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int{3<<counterBits | 1, 3<<counterBits | 3, 3<<counterBits | 4}
+	for _, w := range want {
+		if got, err := a.Next(); err != nil || got != w {
+			t.Errorf("Next() = %d, %v; want %d", got, err, w)
+		}
+	}
+	a.next = maxCounter
+	if got, _ := a.Next(); got != 3<<counterBits|maxCounter || got > 1<<31-1 {
+		t.Errorf("Next() = %d, want the last refId of the instance", got)
+	}
+	if got, _ := a.Next(); got != 3<<counterBits|1 {
+		t.Errorf("Next() after exhaustion = %d, want %d", got, 3<<counterBits|1)
+	}
+	if _, err := NewAllocator(MaxInstance+1, nil); err == nil {
+		t.Errorf("NewAllocator() accepted too big instance")
+	}
+}
+
+func TestAllocator_Exhausted(t *testing.T) {
+	a, _ := NewAllocator(0, func(int) bool { return true })
+	if _, err := a.Next(); !errors.Is(err, ErrNoRefIds) {
+		t.Errorf("Next() error = %v, want %v", err, ErrNoRefIds)
+	}
+}
+
+func TestAllocator_Persisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "refid")
+	a, err := OpenAllocator(1, path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var last int
+	for i := 0; i < reserveBlock+5; i++ {
+		if last, err = a.Next(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	restarted, err := OpenAllocator(1, path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := restarted.Next(); got <= last {
+		t.Errorf("Next() after restart = %d, want greater than %d", got, last)
+	}
+}
+
+func TestAllocator_RestartWithoutPath(t *testing.T) {
+	start := time.Now()
+	a, err := openAllocator(1, "", nil, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var last int
+	for i := 0; i < 100; i++ {
+		if last, err = a.Next(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the previous run lived longer than it allocated refIds
+	restarted, err := openAllocator(1, "", nil, start.Add(time.Minute*5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := restarted.Next(); got <= last {
+		t.Errorf("Next() after restart = %d, want greater than %d", got, last)
+	}
+	// orders open on the exchange are ahead of the counter if the previous run allocated faster
+	restarted.Advance([]int{last + 1000, 2<<counterBits | maxCounter - 1})
+	if got, _ := restarted.Next(); got != last+1001 {
+		t.Errorf("Next() after Advance() = %d, want %d", got, last+1001)
+	}
+}
+
+func TestAllocator_Release(t *testing.T) {
+	a, err := openAllocator(1, "", nil, time.Unix(0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _ := a.Next()
+	second, _ := a.Next()
+	// only the latest refId is allocated again
+	a.Release(first)
+	a.Release(second)
+	if got, _ := a.Next(); got != second {
+		t.Errorf("Next() after Release() = %d, want %d", got, second)
+	}
+	if got, _ := a.Next(); got != second+1 {
+		t.Errorf("Next() = %d, want %d", got, second+1)
+	}
+}
+
+func TestAllocator_Concurrent(t *testing.T) {
+	a, _ := NewAllocator(0, nil)
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				refId, _ := a.Next()
+				mu.Lock()
+				if seen[refId] {
+					t.Errorf("Next() returned %d twice", refId)
+				}
+				seen[refId] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -1,7 +1,11 @@
 package orders
 
 import (
+	"bth-trader/internal/decimal"
 	"bth-trader/internal/entities"
+	"errors"
+	"fmt"
+	"github.com/ltunc/go-observer/observer"
 	"log"
 	"sync"
 	"time"
@@ -11,18 +15,69 @@ import (
 // after that time canceled orders removed from the storage
 const cancelTtl time.Duration = time.Second * 60
 
+// ErrDuplicateClientId is returned when the client order id is already used by another order
+var ErrDuplicateClientId = errors.New("client order id is used by another order")
+
+// Store is a storage of orders used by the trader
+// Storage keeps orders only in the memory, FileStorage also keeps them on disk to survive restarts.
+type Store interface {
+	Notify(order *entities.Order)
+	Add(order *entities.Order) error
+	Insert(order *entities.Order) error
+	Remove(refId int)
+	Find(refId int) (*entities.Order, bool)
+	ByOrderId(orderId string) (*entities.Order, bool)
+	ByClientOrderId(clientOrderId string) (*entities.Order, bool)
+	Select(match func(o *entities.Order) bool) []*entities.Order
+	History(refId int) ([]Transition, bool)
+	MarkReplacing(orderId string)
+	UnmarkReplacing(orderId string)
+	Updates() *observer.Subject[*entities.Order]
+	Claim(key, fingerprint string, refId int, ttl time.Duration) (int, bool, error)
+	Release(key string)
+	ByIdempotencyKey(key string) (*entities.Order, bool)
+	List(q Query) ([]Listed, string, error)
+	Cleanup()
+}
+
 // Storage stores orders in the memory and provides access to them
 // implements Observer interface, so it can be subscribed to new orders from the Dispatcher
 type Storage struct {
 	buffer   map[int]*entities.Order
 	deleteAt map[int]time.Time
-	mu       *sync.Mutex
+	// replaced contains ids of orders replaced after editing, updates for them are ignored
+	replaced map[string]bool
+	// history contains transitions of orders' statuses in order of their appearance
+	history map[int][]Transition
+	// byOrderId and byClientId are indexes of refIds by ids of orders on the exchange and ids set by clients
+	byOrderId  map[string]int
+	byClientId map[string]int
+	// keys are idempotency keys of placed orders
+	keys map[string]*idempotencyKey
+	// times contains times of creation and the last update of orders in the storage
+	times map[int]orderTimes
+	// updates notifies observers about merged states of orders accepted by the storage
+	updates observer.Subject[*entities.Order]
+	mu      *sync.Mutex
 }
 
-func Cleanup(s *Storage) {
+// Cleanup removes old finished orders from the storage
+func Cleanup(s Store) {
+	s.Cleanup()
+}
+
+// Cleanup removes finished orders from the storage after cancelTtl
+func (s *Storage) Cleanup() {
+	s.cleanup()
+}
+
+// cleanup removes old finished orders and returns refIds of removed orders
+func (s *Storage) cleanup() []int {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	now := time.Now()
+	s.expireKeys(now)
+	var removed []int
 	for k, o := range s.buffer {
 		// ignore orders in progress
 		if o.Status == "pending" || o.Status == "open" || o.Status == "opened" {
@@ -30,13 +85,14 @@ func Cleanup(s *Storage) {
 		}
 		if dt, ok := s.deleteAt[k]; ok {
 			if dt.Sub(now) < 0 {
-				delete(s.buffer, k)
-				delete(s.deleteAt, k)
+				s.remove(k)
+				removed = append(removed, k)
 			}
 		} else {
 			s.deleteAt[k] = now.Add(cancelTtl)
 		}
 	}
+	return removed
 }
 
 // NewStorage creates new Storage object ready to store orders
@@ -45,33 +101,233 @@ func NewStorage() *Storage {
 		buffer:   make(map[int]*entities.Order),
 		mu:       &sync.Mutex{},
 		deleteAt: make(map[int]time.Time),
+		replaced: make(map[string]bool),
+		history:  make(map[int][]Transition),
+		keys:     make(map[string]*idempotencyKey),
+		times:    make(map[int]orderTimes),
 	}
 }
 
 // Notify notifies the storage about new order or an update for it
 func (s *Storage) Notify(order *entities.Order) {
-	s.Add(order)
+	if err := s.Add(order); err != nil {
+		log.Printf("update of order %d is rejected: %v", order.RefId, err)
+	}
 }
 
 // Add adds an order to the storage
-func (s *Storage) Add(order *entities.Order) {
+// An update of a known order is merged into it field by field,
+// returns ErrRegression if the update moves the order back in its lifecycle, such update is ignored.
+func (s *Storage) Add(order *entities.Order) error {
+	stored, _, err := s.apply(order)
+	s.fire(stored)
+	return err
+}
+
+// Insert adds a new order if its client order id is not used by another order
+// The id is checked and taken under one lock, so concurrent orders with the same id cannot both be added.
+// Returns ErrDuplicateClientId if the id is taken.
+func (s *Storage) Insert(order *entities.Order) error {
+	stored, _, err := s.insert(order)
+	s.fire(stored)
+	return err
+}
+
+// Updates returns the subject which notifies about merged states of orders after they are stored
+// Updates ignored or rejected by the storage are not sent.
+func (s *Storage) Updates() *observer.Subject[*entities.Order] {
+	return &s.updates
+}
+
+// fire notifies observers about the stored state of the order, nil if the update was ignored
+func (s *Storage) fire(stored *entities.Order) {
+	if stored != nil {
+		s.updates.Fire(stored)
+	}
+}
+
+// insert checks the client order id and merges the order into the storage like apply
+func (s *Storage) insert(order *entities.Order) (*entities.Order, *Transition, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if order.ClientOrderId != "" {
+		s.ensureIndexes()
+		if refId, ok := s.byClientId[order.ClientOrderId]; ok && refId != order.RefId {
+			return nil, nil, fmt.Errorf("%w: %q", ErrDuplicateClientId, order.ClientOrderId)
+		}
+	}
+	return s.merge(order)
+}
+
+// apply merges the order into the storage,
+// returns the stored state of the order and the transition of its status if the status changed.
+// The stored state is nil if the order was ignored.
+func (s *Storage) apply(order *entities.Order) (*entities.Order, *Transition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.merge(order)
+}
+
+// merge merges the order into the storage, the storage should be locked
+func (s *Storage) merge(order *entities.Order) (*entities.Order, *Transition, error) {
 	if order.RefId == 0 {
 		log.Printf("an order without RefId, ignore: %v", order)
 		// store only orders with refId
-		return
+		return nil, nil, nil
+	}
+	if s.replaced[order.OrderId] {
+		// the edited order keeps the same refId, its updates shall not overwrite the replacement
+		return nil, nil, nil
+	}
+	now := time.Now()
+	prev, known := s.buffer[order.RefId]
+	// the replacement of an edited order is a new order on the exchange with the same refId,
+	// it reopens the entry even if the cancellation of the edited order was applied before
+	replacement := known && prev.OrderId != "" && order.OrderId != "" && order.OrderId != prev.OrderId &&
+		(order.ReplacedId == prev.OrderId || s.replaced[prev.OrderId])
+	if known && (prev.OrderId == order.OrderId || prev.OrderId == "" || order.ReplacedId != "" || replacement) {
+		if err := checkTransition(prev.Status, order.Status); err != nil && !replacement {
+			return nil, nil, fmt.Errorf("%w: %s -> %s", err, prev.Status, order.Status)
+		}
+	} else {
+		// a new order, or refId is reused by another order
+		known = false
+		delete(s.history, order.RefId)
+	}
+	if order.ReplacedId != "" {
+		if s.replaced == nil {
+			s.replaced = make(map[string]bool)
+		}
+		s.replaced[order.ReplacedId] = true
+	}
+	from := ""
+	if known {
+		from = prev.Status
+		// updates of an order come only with changed fields, so they are merged into a copy of known state
+		merged := *prev
+		if replacement {
+			// execution and closing of the edited order do not belong to the replacement
+			merged.VolumeExec, merged.Cost, merged.Fee, merged.AvgPrice = decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero
+			merged.CloseTime, merged.CancelReason = time.Time{}, ""
+		}
+		merged.Merge(order)
+		order = &merged
+	}
+	var tr *Transition
+	if order.Status != from {
+		tr = &Transition{
+			OrderId: order.OrderId,
+			From:    from,
+			To:      order.Status,
+			Time:    now,
+		}
+		s.addTransition(order.RefId, *tr)
+	}
+	s.put(order)
+	s.touch(order.RefId, now, !known)
+	return order, tr, nil
+}
+
+// put puts the order to the buffer and updates indexes
+func (s *Storage) put(order *entities.Order) {
+	s.ensureIndexes()
+	if prev, ok := s.buffer[order.RefId]; ok {
+		s.unindex(prev)
 	}
 	s.buffer[order.RefId] = order
+	s.index(order)
+	s.trackKey(order)
+}
+
+// ensureIndexes builds indexes if they were not built yet
+func (s *Storage) ensureIndexes() {
+	if s.byOrderId != nil {
+		return
+	}
+	s.byOrderId = make(map[string]int)
+	s.byClientId = make(map[string]int)
+	for _, order := range s.buffer {
+		s.index(order)
+	}
+}
+
+func (s *Storage) index(order *entities.Order) {
+	if order.OrderId != "" {
+		s.byOrderId[order.OrderId] = order.RefId
+	}
+	if order.ClientOrderId != "" {
+		s.byClientId[order.ClientOrderId] = order.RefId
+	}
+}
+
+func (s *Storage) unindex(order *entities.Order) {
+	if s.byOrderId[order.OrderId] == order.RefId {
+		delete(s.byOrderId, order.OrderId)
+	}
+	if s.byClientId[order.ClientOrderId] == order.RefId {
+		delete(s.byClientId, order.ClientOrderId)
+	}
+}
+
+func (s *Storage) addTransition(refId int, tr Transition) {
+	if s.history == nil {
+		s.history = make(map[int][]Transition)
+	}
+	s.history[refId] = append(s.history[refId], tr)
+}
+
+// History returns transitions of the order status in order of their appearance
+// returns false as second argument if the order was not found
+func (s *Storage) History(refId int) ([]Transition, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.buffer[refId]; !ok {
+		return nil, false
+	}
+	history := make([]Transition, len(s.history[refId]))
+	copy(history, s.history[refId])
+	return history, true
+}
+
+// MarkReplacing marks the order as being replaced by editing, so updates of it are ignored
+// The exchange may cancel the edited order before it confirms the edit, the cancellation shall not finish the entry.
+// Call UnmarkReplacing if the edit failed.
+func (s *Storage) MarkReplacing(orderId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.replaced == nil {
+		s.replaced = make(map[string]bool)
+	}
+	s.replaced[orderId] = true
+}
+
+// UnmarkReplacing removes the mark set by MarkReplacing, updates of the order are applied again
+func (s *Storage) UnmarkReplacing(orderId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.replaced, orderId)
 }
 
 // Remove removes an order from the storage
 func (s *Storage) Remove(refId int) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if _, ok := s.buffer[refId]; ok {
-		delete(s.buffer, refId)
+	s.remove(refId)
+}
+
+// remove removes an order from the storage, returns false if there was no such order
+func (s *Storage) remove(refId int) bool {
+	order, ok := s.buffer[refId]
+	if !ok {
+		return false
 	}
+	s.ensureIndexes()
+	s.unindex(order)
+	delete(s.buffer, refId)
+	delete(s.deleteAt, refId)
+	delete(s.history, refId)
+	delete(s.times, refId)
+	return true
 }
 
 // Find search an order by its refId in the storage and returns it if found
@@ -90,10 +346,43 @@ func (s *Storage) Find(refId int) (*entities.Order, bool) {
 func (s *Storage) ByOrderId(orderId string) (*entities.Order, bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.byIndex(func() (int, bool) {
+		refId, ok := s.byOrderId[orderId]
+		return refId, ok
+	})
+}
+
+// ByClientOrderId searches an order by id set by the client
+// returns false as second argument if the order was not found
+func (s *Storage) ByClientOrderId(clientOrderId string) (*entities.Order, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.byIndex(func() (int, bool) {
+		refId, ok := s.byClientId[clientOrderId]
+		return refId, ok
+	})
+}
+
+// byIndex returns the order with refId found in an index
+func (s *Storage) byIndex(lookup func() (int, bool)) (*entities.Order, bool) {
+	s.ensureIndexes()
+	refId, ok := lookup()
+	if !ok {
+		return nil, false
+	}
+	order, ok := s.buffer[refId]
+	return order, ok
+}
+
+// Select returns all orders for which match returns true
+func (s *Storage) Select(match func(o *entities.Order) bool) []*entities.Order {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []*entities.Order
 	for _, order := range s.buffer {
-		if order.OrderId == orderId {
-			return order, true
+		if match(order) {
+			result = append(result, order)
 		}
 	}
-	return nil, false
+	return result
 }
@@ -1,8 +1,11 @@
 package orders
 
 import (
+	"bth-trader/internal/decimal"
 	"bth-trader/internal/entities"
+	"errors"
 	"reflect"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -10,7 +13,8 @@ import (
 
 func TestStorage_Add(t *testing.T) {
 	type fields struct {
-		buffer map[int]*entities.Order
+		buffer   map[int]*entities.Order
+		replaced map[string]bool
 	}
 	type args struct {
 		order *entities.Order
@@ -64,10 +68,63 @@ func TestStorage_Add(t *testing.T) {
 				102: {OrderId: "ABC102", RefId: 102, Status: "open"},
 			},
 		},
+		{
+			"keeps pair",
+			fields{buffer: map[int]*entities.Order{
+				104: {OrderId: "ABC104", RefId: 104, Pair: "XBT/EUR", Status: "pending"},
+			}},
+			args{&entities.Order{OrderId: "ABC104", RefId: 104, Status: "open"}},
+			map[int]*entities.Order{
+				104: {OrderId: "ABC104", RefId: 104, Pair: "XBT/EUR", Status: "open"},
+			},
+		},
+		{
+			"merges update",
+			fields{buffer: map[int]*entities.Order{
+				105: {OrderId: "ABC105", RefId: 105, Pair: "XBT/EUR", Side: "buy", Status: "open", Volume: decimal.MustParse("2"), Price: decimal.MustParse("100")},
+			}},
+			args{&entities.Order{OrderId: "ABC105", RefId: 105, Status: "open", VolumeExec: decimal.MustParse("1"), Cost: decimal.MustParse("100"), AvgPrice: decimal.MustParse("100")}},
+			map[int]*entities.Order{
+				105: {OrderId: "ABC105", RefId: 105, Pair: "XBT/EUR", Side: "buy", Status: "open", Volume: decimal.MustParse("2"), VolumeExec: decimal.MustParse("1"), Cost: decimal.MustParse("100"), AvgPrice: decimal.MustParse("100"), Price: decimal.MustParse("100")},
+			},
+		},
+		{
+			"rejects regression",
+			fields{buffer: map[int]*entities.Order{
+				106: {OrderId: "ABC106", RefId: 106, Status: "closed", VolumeExec: decimal.MustParse("2")},
+			}},
+			args{&entities.Order{OrderId: "ABC106", RefId: 106, Status: "open", VolumeExec: decimal.MustParse("1")}},
+			map[int]*entities.Order{
+				106: {OrderId: "ABC106", RefId: 106, Status: "closed", VolumeExec: decimal.MustParse("2")},
+			},
+		},
+		{
+			"replacement",
+			fields{buffer: map[int]*entities.Order{
+				102: {OrderId: "ABC102", RefId: 102, Status: "open"},
+			}},
+			args{&entities.Order{OrderId: "ABC103", RefId: 102, Status: "open", ReplacedId: "ABC102"}},
+			map[int]*entities.Order{
+				102: {OrderId: "ABC103", RefId: 102, Status: "open", ReplacedId: "ABC102"},
+			},
+		},
+		{
+			"replaced",
+			fields{
+				buffer: map[int]*entities.Order{
+					102: {OrderId: "ABC103", RefId: 102, Status: "open", ReplacedId: "ABC102"},
+				},
+				replaced: map[string]bool{"ABC102": true},
+			},
+			args{&entities.Order{OrderId: "ABC102", RefId: 102, Status: "canceled"}},
+			map[int]*entities.Order{
+				102: {OrderId: "ABC103", RefId: 102, Status: "open", ReplacedId: "ABC102"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &Storage{buffer: tt.fields.buffer, mu: &sync.Mutex{}}
+			s := &Storage{buffer: tt.fields.buffer, replaced: tt.fields.replaced, mu: &sync.Mutex{}}
 			s.Add(tt.args.order)
 			if got := s.buffer; !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Add() got buffer %v, want %v", got, tt.want)
@@ -76,6 +133,46 @@ func TestStorage_Add(t *testing.T) {
 	}
 }
 
+func TestStorage_History(t *testing.T) {
+	s := NewStorage()
+	updates := []*entities.Order{
+		{RefId: 107, Status: "pending"},
+		{OrderId: "ABC107", RefId: 107, Status: "open"},
+		{OrderId: "ABC107", RefId: 107, VolumeExec: decimal.MustParse("1")},
+		{OrderId: "ABC107", RefId: 107, Status: "closed"},
+		{OrderId: "ABC107", RefId: 107, Status: "open"},
+	}
+	for _, u := range updates[:4] {
+		if err := s.Add(u); err != nil {
+			t.Fatalf("Add(%v) unexpected error: %v", u, err)
+		}
+	}
+	if err := s.Add(updates[4]); !errors.Is(err, ErrRegression) {
+		t.Errorf("Add() error = %v, want %v", err, ErrRegression)
+	}
+	got, ok := s.History(107)
+	if !ok {
+		t.Fatalf("History() order not found")
+	}
+	want := []Transition{
+		{From: "", To: "pending"},
+		{OrderId: "ABC107", From: "pending", To: "open"},
+		{OrderId: "ABC107", From: "open", To: "closed"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("History() got %v, want %v", got, want)
+	}
+	for i := range want {
+		got[i].Time = time.Time{}
+		if got[i] != want[i] {
+			t.Errorf("History() #%d got %v, want %v", i, got[i], want[i])
+		}
+	}
+	if _, ok := s.History(108); ok {
+		t.Errorf("History() found unknown order")
+	}
+}
+
 func TestStorage_Find(t *testing.T) {
 	type fields struct {
 		buffer map[int]*entities.Order
@@ -278,6 +375,20 @@ func TestStorage_ByOrderId(t *testing.T) {
 	}
 }
 
+func TestStorage_Select(t *testing.T) {
+	s := &Storage{buffer: map[int]*entities.Order{
+		201: {RefId: 201, OrderId: "ABC201", Pair: "XBT/EUR"},
+		199: {RefId: 199, OrderId: "ABC199", Pair: "ETH/EUR"},
+		300: {RefId: 300, OrderId: "ABC300", Pair: "XBT/EUR"},
+	}, mu: &sync.Mutex{}}
+	got := s.Select(func(o *entities.Order) bool { return o.Pair == "XBT/EUR" })
+	sort.Slice(got, func(i, j int) bool { return got[i].RefId < got[j].RefId })
+	want := []*entities.Order{{RefId: 201, OrderId: "ABC201", Pair: "XBT/EUR"}, {RefId: 300, OrderId: "ABC300", Pair: "XBT/EUR"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Select() got %v, want %v", got, want)
+	}
+}
+
 func TestCleanup(t *testing.T) {
 	type args struct {
 		s *Storage
@@ -327,3 +438,126 @@ func TestCleanup(t *testing.T) {
 		})
 	}
 }
+
+func TestStorage_indexes(t *testing.T) {
+	s := NewStorage()
+	_ = s.Add(&entities.Order{RefId: 701, ClientOrderId: "grid-1", Status: "pending"})
+	if _, ok := s.ByOrderId("ABC701"); ok {
+		t.Errorf("ByOrderId() found the order before confirmation")
+	}
+	_ = s.Add(&entities.Order{OrderId: "ABC701", RefId: 701, Status: "open"})
+	_ = s.Add(&entities.Order{OrderId: "ABC702", RefId: 701, Status: "open", ReplacedId: "ABC701"})
+	want := &entities.Order{OrderId: "ABC702", RefId: 701, ClientOrderId: "grid-1", Status: "open", ReplacedId: "ABC701"}
+	if got, ok := s.ByClientOrderId("grid-1"); !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("ByClientOrderId() got %v, want %v", got, want)
+	}
+	if got, ok := s.ByOrderId("ABC702"); !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("ByOrderId() got %v, want %v", got, want)
+	}
+	if _, ok := s.ByOrderId("ABC701"); ok {
+		t.Errorf("ByOrderId() found the replaced order")
+	}
+	s.Remove(701)
+	if _, ok := s.ByClientOrderId("grid-1"); ok {
+		t.Errorf("ByClientOrderId() found removed order")
+	}
+}
+
+func TestStorage_Insert(t *testing.T) {
+	s := NewStorage()
+	// concurrent orders with the same client order id, only one of them is added
+	added := 0
+	wg := &sync.WaitGroup{}
+	mu := &sync.Mutex{}
+	for refId := 1; refId <= 10; refId++ {
+		wg.Add(1)
+		go func(refId int) {
+			defer wg.Done()
+			err := s.Insert(&entities.Order{RefId: refId, ClientOrderId: "grid-1", Status: "pending"})
+			if err == nil {
+				mu.Lock()
+				added++
+				mu.Unlock()
+			} else if !errors.Is(err, ErrDuplicateClientId) {
+				t.Errorf("Insert() error = %v, want ErrDuplicateClientId", err)
+			}
+		}(refId)
+	}
+	wg.Wait()
+	if added != 1 {
+		t.Errorf("Insert() added %d orders with the same client order id, want 1", added)
+	}
+	// updates of the added order are not duplicates
+	o, _ := s.ByClientOrderId("grid-1")
+	if err := s.Insert(&entities.Order{RefId: o.RefId, ClientOrderId: "grid-1", OrderId: "ABC", Status: "open"}); err != nil {
+		t.Errorf("Insert() update error = %v", err)
+	}
+	if err := s.Insert(&entities.Order{RefId: 11, ClientOrderId: "grid-2", Status: "pending"}); err != nil {
+		t.Errorf("Insert() error = %v", err)
+	}
+}
+
+func TestStorage_edit(t *testing.T) {
+	tests := []struct {
+		name    string
+		mark    bool
+		updates []*entities.Order
+	}{
+		{
+			name: "cancel before the edit reply",
+			updates: []*entities.Order{
+				{OrderId: "ABC1", RefId: 801, Status: "canceled"},
+				{OrderId: "ABC2", RefId: 801, Status: "open", ReplacedId: "ABC1"},
+				{OrderId: "ABC2", RefId: 801, Status: "open", VolumeExec: decimal.MustParse("0.5")},
+			},
+		},
+		{
+			name: "replacement before the edit reply",
+			mark: true,
+			updates: []*entities.Order{
+				{OrderId: "ABC1", RefId: 801, Status: "canceled"},
+				{OrderId: "ABC2", RefId: 801, Status: "open", VolumeExec: decimal.MustParse("0.5")},
+				{OrderId: "ABC2", RefId: 801, Status: "open", ReplacedId: "ABC1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStorage()
+			_ = s.Add(&entities.Order{OrderId: "ABC1", RefId: 801, Status: "open", Volume: decimal.MustParse("1"), VolumeExec: decimal.MustParse("0.2")})
+			if tt.mark {
+				s.MarkReplacing("ABC1")
+			}
+			for _, u := range tt.updates {
+				if err := s.Add(u); err != nil {
+					t.Fatalf("Add(%v) error = %v", u, err)
+				}
+			}
+			got, _ := s.Find(801)
+			if got.OrderId != "ABC2" || got.Status != "open" || got.VolumeExec != decimal.MustParse("0.5") || got.Volume != decimal.MustParse("1") {
+				t.Errorf("Find() = %+v, want the open replacement ABC2", got)
+			}
+			if history, _ := s.History(801); len(history) == 0 || history[0].To != "open" || history[0].OrderId != "ABC1" {
+				t.Errorf("History() = %v, want history of the edited order kept", history)
+			}
+		})
+	}
+}
+
+func TestStorage_Updates(t *testing.T) {
+	s := NewStorage()
+	obs := &mockObserver{}
+	s.Updates().Subscribe(obs)
+	_ = s.Add(&entities.Order{OrderId: "ABC1", RefId: 901, Status: "open", Volume: decimal.MustParse("1")})
+	_ = s.Add(&entities.Order{OrderId: "ABC1", RefId: 901, Status: "canceled"})
+	// regression is rejected, updates without refId are ignored
+	_ = s.Add(&entities.Order{OrderId: "ABC1", RefId: 901, Status: "open"})
+	_ = s.Add(&entities.Order{OrderId: "ABC9", Status: "open"})
+	want := []*entities.Order{
+		{OrderId: "ABC1", RefId: 901, Status: "open", Volume: decimal.MustParse("1")},
+		{OrderId: "ABC1", RefId: 901, Status: "canceled", Volume: decimal.MustParse("1")},
+	}
+	if !reflect.DeepEqual(obs.calls, want) {
+		t.Errorf("notified about %v, want %v", obs.calls, want)
+	}
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"sync/atomic"
+	"time"
+)
+
+// Activity tracks requests of gRPC clients
+// It is used to detect that clients are gone, e.g. crashed.
+type Activity struct {
+	last    atomic.Int64
+	streams atomic.Int32
+}
+
+// Unary is a gRPC interceptor that records time of each request
+func (a *Activity) Unary(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	a.last.Store(time.Now().UnixNano())
+	return handler(ctx, req)
+}
+
+// Stream is a gRPC interceptor that counts opened streams
+func (a *Activity) Stream(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	a.streams.Add(1)
+	defer func() {
+		a.streams.Add(-1)
+		a.last.Store(time.Now().UnixNano())
+	}()
+	return handler(srv, ss)
+}
+
+// Alive checks if any client has an opened stream or sent a request during the window
+func (a *Activity) Alive(window time.Duration) bool {
+	if a.streams.Load() > 0 {
+		return true
+	}
+	return time.Since(time.Unix(0, a.last.Load())) < window
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"bth-trader/api/bth"
+	"bth-trader/internal/balances"
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+)
+
+// GetBalances returns balances loaded by the tracker, they are not requested from the exchange on every call
+func (s *TraderServer) GetBalances(_ context.Context, req *bth.BalancesRequest) (*bth.BalancesResponse, error) {
+	snapshot, err := s.balances.Snapshot()
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return balancesResponse(snapshot, s.assetFilter(req.Assets)), nil
+}
+
+func (s *TraderServer) GetTradeBalance(_ context.Context, req *bth.TradeBalanceRequest) (*bth.TradeBalanceResponse, error) {
+	tb, err := s.balances.TradeBalance(req.Asset)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "cannot get trade balance: %v", err)
+	}
+	return &bth.TradeBalanceResponse{
+		EquivalentBalance: tb.EquivalentBalance.String(),
+		TradeBalance:      tb.TradeBalance.String(),
+		Margin:            tb.Margin.String(),
+		UnrealizedPnl:     tb.UnrealizedPnL.String(),
+		CostBasis:         tb.CostBasis.String(),
+		Valuation:         tb.Valuation.String(),
+		Equity:            tb.Equity.String(),
+		FreeMargin:        tb.FreeMargin.String(),
+		MarginLevel:       tb.MarginLevel.String(),
+		Unexecuted:        tb.Unexecuted.String(),
+	}, nil
+}
+
+func (s *TraderServer) StreamBalances(req *bth.BalancesRequest, stream bth.Trader_StreamBalancesServer) error {
+	match := s.assetFilter(req.Assets)
+	var sent *bth.BalancesResponse
+	for {
+		snapshot, changed, err := s.balances.Watch()
+		switch {
+		case errors.Is(err, balances.ErrNotLoaded):
+			// balances are not loaded from the exchange yet
+		case err != nil:
+			return status.Error(codes.Unavailable, err.Error())
+		default:
+			resp := balancesResponse(snapshot, match)
+			// changes of other assets are not sent
+			if sent == nil || !sameBalances(sent.Balances, resp.Balances) {
+				if err := stream.Send(resp); err != nil {
+					log.Printf("cannot send message to outgoing stream: %v", err)
+					return err
+				}
+				sent = resp
+			}
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
+
+// assetFilter matches assets by any of their names, all assets match if names are empty
+func (s *TraderServer) assetFilter(names []string) func(asset string) bool {
+	if len(names) == 0 {
+		return func(string) bool { return true }
+	}
+	wanted := make(map[string]bool, len(names))
+	for _, n := range names {
+		wanted[s.pairs.AssetName(n)] = true
+	}
+	return func(asset string) bool {
+		return wanted[s.pairs.AssetName(asset)]
+	}
+}
+
+func balancesResponse(snapshot *balances.Snapshot, match func(asset string) bool) *bth.BalancesResponse {
+	resp := &bth.BalancesResponse{
+		Updated:  unixMilli(snapshot.Updated),
+		Sequence: snapshot.Seq,
+	}
+	for _, b := range snapshot.Balances {
+		if !match(b.Asset) {
+			continue
+		}
+		resp.Balances = append(resp.Balances, &bth.Balance{
+			Asset:     b.Asset,
+			Total:     b.Total.String(),
+			Reserved:  b.Reserved.String(),
+			Available: b.Available.String(),
+		})
+	}
+	return resp
+}
+
+func sameBalances(a, b []*bth.Balance) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Asset != b[i].Asset || a[i].Total != b[i].Total || a[i].Reserved != b[i].Reserved || a[i].Available != b[i].Available {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"bth-trader/api/bth"
+	"bth-trader/internal/entities"
+	"bth-trader/internal/kraken"
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+)
+
+// maxBatchSize is maximum number of orders in one batch request
+const maxBatchSize = 100
+
+// batchWorkers is maximum number of orders of one batch placed concurrently
+const batchWorkers = 10
+
+// AddOrders places orders of the batch concurrently, at most batchWorkers at a time
+// Orders are independent, failure of one order does not affect others.
+func (s *TraderServer) AddOrders(ctx context.Context, req *bth.AddOrdersRequest) (*bth.AddOrdersResponse, error) {
+	if len(req.Orders) == 0 || len(req.Orders) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "number of orders should be from 1 to %d", maxBatchSize)
+	}
+	if err := s.checkConnection(); err != nil {
+		return nil, err
+	}
+	results := make([]*bth.AddOrderResult, len(req.Orders))
+	sem := make(chan struct{}, batchWorkers)
+	wg := &sync.WaitGroup{}
+	for k, o := range req.Orders {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(k int, o *bth.AddOrderRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			resp, err := s.AddOrder(ctx, o)
+			if err != nil {
+				results[k] = addOrderFailure(err)
+				return
+			}
+			results[k] = &bth.AddOrderResult{Status: resp.Status, RefId: resp.RefId, OrderId: resp.OrderId}
+		}(k, o)
+	}
+	wg.Wait()
+	return &bth.AddOrdersResponse{Results: results}, nil
+}
+
+// addOrderFailure converts an error of AddOrder to the result
+// The order may be placed even if the confirmation was not received in time, so refId is kept
+func addOrderFailure(err error) *bth.AddOrderResult {
+	st := status.Convert(err)
+	result := &bth.AddOrderResult{Status: "error", Error: st.Message()}
+	for _, d := range st.Details() {
+		if pending, ok := d.(*bth.AddOrderResponse); ok {
+			result.Status = pending.Status
+			result.RefId = pending.RefId
+		}
+	}
+	return result
+}
+
+// CancelOrders cancels known orders of the batch with a single request to the exchange
+// Unknown orders fail separately, the exchange cancels either all requested orders or none of them.
+func (s *TraderServer) CancelOrders(ctx context.Context, req *bth.CancelOrdersRequest) (*bth.CancelOrdersResponse, error) {
+	if len(req.RefIds) == 0 || len(req.RefIds) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "number of orders should be from 1 to %d", maxBatchSize)
+	}
+	if err := s.checkConnection(); err != nil {
+		return nil, err
+	}
+	results := make([]*bth.CancelOrderResult, len(req.RefIds))
+	var found []*entities.Order
+	var sent []*bth.CancelOrderResult
+	for k, refId := range req.RefIds {
+		results[k] = &bth.CancelOrderResult{RefId: refId}
+		order, ok := s.storage.Find(int(refId))
+		if !ok || order.OrderId == "" {
+			results[k].Status = "error"
+			results[k].Error = "order not found"
+			continue
+		}
+		found = append(found, order)
+		sent = append(sent, results[k])
+	}
+	if len(found) == 0 {
+		return &bth.CancelOrdersResponse{Results: results}, nil
+	}
+	reply, err := s.ws.CancelOrder(ctx, kraken.NewCancelOrderMsg(found, s.tokens.Token()))
+	for _, result := range sent {
+		if err != nil {
+			result.Status = "error"
+			result.Error = err.Error()
+		} else {
+			result.Status = reply.Status
+		}
+	}
+	return &bth.CancelOrdersResponse{Results: results}, nil
+}
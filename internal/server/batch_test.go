@@ -0,0 +1,266 @@
+package server
+
+import (
+	"bth-trader/api/bth"
+	"bth-trader/internal/assets"
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/entities"
+	"bth-trader/internal/kraken"
+	"bth-trader/internal/orders"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ltunc/go-observer/observer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockWs confirms orders through the dispatcher like the orders stream of the exchange
+type mockWs struct {
+	od *observer.Subject[*entities.Order]
+	// rejectVolume is volume of orders rejected by the exchange
+	rejectVolume string
+	cancelErr    error
+	addErr       error
+	mu           sync.Mutex
+	inFlight     int
+	maxInFlight  int
+	cancels      []kraken.CancelOrderMsg
+	edits        []kraken.EditOrderMsg
+}
+
+func (m *mockWs) State() kraken.ConnState { return kraken.StateConnected }
+func (m *mockWs) LastSeen() time.Time     { return time.Now() }
+func (m *mockWs) Latency() time.Duration  { return 0 }
+func (m *mockWs) EditOrder(_ context.Context, msg kraken.EditOrderMsg) (kraken.Reply, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.edits = append(m.edits, msg)
+	return kraken.Reply{Status: "ok", TxId: "O2", OriginalTxId: msg.OrderId}, nil
+}
+
+func (m *mockWs) ValidateOrder(context.Context, kraken.AddOrderMsg) (kraken.Reply, error) {
+	return kraken.Reply{Status: "ok"}, nil
+}
+
+func (m *mockWs) CancelAll(context.Context, kraken.CancelAllMsg) (kraken.Reply, error) {
+	return kraken.Reply{Status: "ok"}, nil
+}
+
+func (m *mockWs) CancelOrder(_ context.Context, msg kraken.CancelOrderMsg) (kraken.Reply, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cancels = append(m.cancels, msg)
+	if m.cancelErr != nil {
+		return kraken.Reply{}, m.cancelErr
+	}
+	return kraken.Reply{Status: "ok"}, nil
+}
+
+func (m *mockWs) AddOrder(msg kraken.AddOrderMsg) error {
+	if m.addErr != nil {
+		return m.addErr
+	}
+	refId, _ := strconv.Atoi(msg.UserRef)
+	m.mu.Lock()
+	m.inFlight++
+	if m.inFlight > m.maxInFlight {
+		m.maxInFlight = m.inFlight
+	}
+	m.mu.Unlock()
+	go func() {
+		// later orders are confirmed earlier
+		time.Sleep(time.Millisecond * time.Duration(20-refId%10))
+		m.mu.Lock()
+		m.inFlight--
+		m.mu.Unlock()
+		if msg.Volume == m.rejectVolume {
+			m.od.Fire(&entities.Order{RefId: refId, Status: "error", Error: "EOrder:Insufficient funds"})
+			return
+		}
+		m.od.Fire(&entities.Order{RefId: refId, OrderId: fmt.Sprintf("O%d", refId), Status: "open"})
+	}()
+	return nil
+}
+
+type mockPairsSource struct{}
+
+func (mockPairsSource) AssetPairs() (map[string]*kraken.AssetPair, error) {
+	return map[string]*kraken.AssetPair{
+		"XXBTZEUR": {
+			Name: "XXBTZEUR", AltName: "XBTEUR", WsName: "XBT/EUR", Base: "XXBT", Quote: "ZEUR",
+			PairDecimals: 1, LotDecimals: 8, TickSize: decimal.MustParse("0.1"), OrderMin: decimal.MustParse("0.0001"), CostMin: decimal.MustParse("0.45"), Status: kraken.PairOnline,
+		},
+	}, nil
+}
+
+func (mockPairsSource) Assets() (map[string]*kraken.Asset, error) {
+	return map[string]*kraken.Asset{}, nil
+}
+
+func newTestServer(t *testing.T) (*TraderServer, *mockWs) {
+	od := orders.NewDispatcher()
+	storage := orders.NewStorage()
+	od.Subscribe(storage)
+	refIds, err := orders.NewAllocator(0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairs := assets.NewRegistry(mockPairsSource{})
+	if err := pairs.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	ws := &mockWs{od: od, rejectVolume: "0.5"}
+	return &TraderServer{
+		ws:                ws,
+		tokens:            kraken.NewTokenManager(nil),
+		refIds:            refIds,
+		storage:           storage,
+		pairs:             pairs,
+		IdempotencyWindow: defaultIdempotencyWindow,
+	}, ws
+}
+
+func TestTraderServer_AddOrders(t *testing.T) {
+	s, ws := newTestServer(t)
+	req := &bth.AddOrdersRequest{}
+	for i := 0; i < 30; i++ {
+		req.Orders = append(req.Orders, &bth.AddOrderRequest{Pair: "XBTEUR", Direction: "buy", Price: "20000", Volume: "0.01", Tag: strconv.Itoa(i)})
+	}
+	// rejected by the exchange
+	req.Orders[3].Volume = "0.5"
+	// rejected before sending
+	req.Orders[7].Volume = "0.00001"
+	resp, err := s.AddOrders(context.Background(), req)
+	if err != nil {
+		t.Fatalf("AddOrders() error = %v", err)
+	}
+	if len(resp.Results) != len(req.Orders) {
+		t.Fatalf("AddOrders() got %d results, want %d", len(resp.Results), len(req.Orders))
+	}
+	for i, r := range resp.Results {
+		switch i {
+		case 3, 7:
+			if r.Status != "error" || r.Error == "" {
+				t.Errorf("result %d = %v, want error", i, r)
+			}
+			continue
+		}
+		// results are in order of the request even though later orders are confirmed earlier
+		stored, ok := s.storage.Find(int(r.RefId))
+		if r.Status != "open" || !ok || stored.Tag != strconv.Itoa(i) || r.OrderId != stored.OrderId {
+			t.Errorf("result %d = %v, stored order %v", i, r, stored)
+		}
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.maxInFlight > batchWorkers {
+		t.Errorf("%d orders were placed concurrently, want at most %d", ws.maxInFlight, batchWorkers)
+	}
+}
+
+func TestTraderServer_CancelOrders(t *testing.T) {
+	tests := []struct {
+		name      string
+		cancelErr error
+		want      []*bth.CancelOrderResult
+	}{
+		{
+			name: "partial",
+			want: []*bth.CancelOrderResult{
+				{RefId: 2, Status: "ok"},
+				{RefId: 5, Status: "error", Error: "order not found"},
+				{RefId: 1, Status: "ok"},
+				{RefId: 3, Status: "error", Error: "order not found"},
+			},
+		},
+		{
+			name:      "rejected",
+			cancelErr: fmt.Errorf("%w: EOrder:Unknown order", kraken.ErrRejected),
+			want: []*bth.CancelOrderResult{
+				{RefId: 2, Status: "error", Error: "request rejected by the server: EOrder:Unknown order"},
+				{RefId: 5, Status: "error", Error: "order not found"},
+				{RefId: 1, Status: "error", Error: "request rejected by the server: EOrder:Unknown order"},
+				{RefId: 3, Status: "error", Error: "order not found"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ws := newTestServer(t)
+			ws.cancelErr = tt.cancelErr
+			s.storage.Add(&entities.Order{RefId: 1, OrderId: "O1", Status: "open"})
+			s.storage.Add(&entities.Order{RefId: 2, OrderId: "O2", Status: "open"})
+			// not confirmed yet
+			s.storage.Add(&entities.Order{RefId: 3, Status: "pending"})
+			resp, err := s.CancelOrders(context.Background(), &bth.CancelOrdersRequest{RefIds: []int32{2, 5, 1, 3}})
+			if err != nil {
+				t.Fatalf("CancelOrders() error = %v", err)
+			}
+			if len(resp.Results) != len(tt.want) {
+				t.Fatalf("CancelOrders() got %v, want %v", resp.Results, tt.want)
+			}
+			for i := range tt.want {
+				got := resp.Results[i]
+				if got.RefId != tt.want[i].RefId || got.Status != tt.want[i].Status || got.Error != tt.want[i].Error {
+					t.Errorf("result %d = %v, want %v", i, got, tt.want[i])
+				}
+			}
+			if len(ws.cancels) != 1 || !reflect.DeepEqual(ws.cancels[0].TxId, []string{"O2", "O1"}) {
+				t.Errorf("sent cancel requests %v, want one with O2, O1", ws.cancels)
+			}
+		})
+	}
+}
+
+func TestTraderServer_BatchSize(t *testing.T) {
+	s, _ := newTestServer(t)
+	if _, err := s.CancelOrders(context.Background(), &bth.CancelOrdersRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CancelOrders() without refIds error = %v, want InvalidArgument", err)
+	}
+	if _, err := s.AddOrders(context.Background(), &bth.AddOrdersRequest{Orders: make([]*bth.AddOrderRequest, maxBatchSize+1)}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("AddOrders() with %d orders error = %v, want InvalidArgument", maxBatchSize+1, err)
+	}
+}
+
+func TestTraderServer_BatchNotConnected(t *testing.T) {
+	s := &TraderServer{ws: kraken.NewWsClient("")}
+	if _, err := s.AddOrders(context.Background(), &bth.AddOrdersRequest{Orders: []*bth.AddOrderRequest{{}}}); status.Code(err) != codes.Unavailable {
+		t.Errorf("AddOrders() error = %v, want Unavailable", err)
+	}
+	if _, err := s.CancelOrders(context.Background(), &bth.CancelOrdersRequest{RefIds: []int32{1}}); status.Code(err) != codes.Unavailable {
+		t.Errorf("CancelOrders() error = %v, want Unavailable", err)
+	}
+}
+
+func TestAddOrderFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want *bth.AddOrderResult
+	}{
+		{
+			name: "rejected",
+			err:  status.Errorf(codes.InvalidArgument, "invalid volume"),
+			want: &bth.AddOrderResult{Status: "error", Error: "invalid volume"},
+		},
+		{
+			name: "not confirmed",
+			err:  waitError(errors.New("timeout"), 42),
+			want: &bth.AddOrderResult{Status: "pending", RefId: 42, Error: "no confirmation for order with refId 42: timeout"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := addOrderFailure(tt.err)
+			if got.Status != tt.want.Status || got.RefId != tt.want.RefId || got.Error != tt.want.Error {
+				t.Errorf("addOrderFailure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
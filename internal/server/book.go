@@ -0,0 +1,109 @@
+package server
+
+import (
+	"bth-trader/api/bth"
+	"bth-trader/internal/book"
+	"bth-trader/internal/entities"
+	"bth-trader/internal/market"
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"time"
+)
+
+// bookTimeout is time to wait for the snapshot of the order book if the client did not set a deadline
+const bookTimeout = time.Second * 10
+
+func (s *TraderServer) GetOrderBook(ctx context.Context, req *bth.OrderBookRequest) (*bth.OrderBook, error) {
+	depth := bookDepth(req)
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, bookTimeout)
+		defer cancel()
+	}
+	if err := s.acquireBook(req.Pair, depth); err != nil {
+		return nil, err
+	}
+	defer s.releaseBook(req.Pair, depth)
+	snapshot, err := s.books.Wait(ctx, req.Pair, depth)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, status.Errorf(codes.DeadlineExceeded, "order book of %s is not received", req.Pair)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return orderBookResponse(snapshot), nil
+}
+
+func (s *TraderServer) StreamOrderBook(req *bth.OrderBookRequest, stream bth.Trader_StreamOrderBookServer) error {
+	depth := bookDepth(req)
+	if err := s.acquireBook(req.Pair, depth); err != nil {
+		return err
+	}
+	defer s.releaseBook(req.Pair, depth)
+	var sent uint64
+	for {
+		snapshot, changed, err := s.books.Watch(req.Pair, depth)
+		switch {
+		case errors.Is(err, book.ErrNotSynced):
+			// the book is waiting for a snapshot
+		case err != nil:
+			return status.Error(codes.Unavailable, err.Error())
+		case snapshot.Seq != sent:
+			if err := stream.Send(orderBookResponse(snapshot)); err != nil {
+				log.Printf("cannot send message to outgoing stream: %v", err)
+				return err
+			}
+			sent = snapshot.Seq
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
+
+func bookDepth(req *bth.OrderBookRequest) int {
+	if req.Depth == 0 {
+		return book.DefaultDepth
+	}
+	return int(req.Depth)
+}
+
+func (s *TraderServer) acquireBook(pair string, depth int) error {
+	if err := s.books.Acquire(pair, depth); err != nil {
+		if errors.Is(err, market.ErrInvalidTopic) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return status.Errorf(codes.Unavailable, "cannot subscribe to order book: %v", err)
+	}
+	return nil
+}
+
+func (s *TraderServer) releaseBook(pair string, depth int) {
+	if err := s.books.Release(pair, depth); err != nil {
+		log.Printf("cannot release order book: %v", err)
+	}
+}
+
+func orderBookResponse(s *book.Snapshot) *bth.OrderBook {
+	return &bth.OrderBook{
+		Pair:     s.Pair,
+		Depth:    int32(s.Depth),
+		Asks:     bookLevels(s.Asks),
+		Bids:     bookLevels(s.Bids),
+		Updated:  unixMilli(s.Updated),
+		Sequence: s.Seq,
+	}
+}
+
+func bookLevels(levels []entities.BookLevel) []*bth.OrderBookLevel {
+	result := make([]*bth.OrderBookLevel, 0, len(levels))
+	for _, l := range levels {
+		result = append(result, &bth.OrderBookLevel{Price: l.Price.String(), Volume: l.Volume.String(), Time: unixMilli(l.Time)})
+	}
+	return result
+}
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"bth-trader/api/bth"
+	"bth-trader/internal/entities"
+	"bth-trader/internal/market"
+	"context"
+	"errors"
+	"github.com/ltunc/go-observer/observer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+)
+
+// defaultInterval is length of candles in minutes if the request does not set it
+const defaultInterval = 1
+
+func (s *TraderServer) StreamTicker(req *bth.StreamMarketRequest, stream bth.Trader_StreamTickerServer) error {
+	topics := marketTopics(market.ChannelTicker, req.Pairs, 0)
+	match := func(t *entities.Ticker) bool {
+		return containsTopic(topics, market.Topic{Channel: market.ChannelTicker, Pair: t.Pair})
+	}
+	return streamMarket(stream.Context(), s, s.market.Tickers, topics, match, func(t *entities.Ticker) error {
+		return stream.Send(&bth.Ticker{
+			Pair:       t.Pair,
+			Ask:        t.Ask.String(),
+			AskVolume:  t.AskVolume.String(),
+			Bid:        t.Bid.String(),
+			BidVolume:  t.BidVolume.String(),
+			Last:       t.Last.String(),
+			LastVolume: t.LastVolume.String(),
+			Volume:     t.Volume.String(),
+			Vwap:       t.Vwap.String(),
+			Trades:     int32(t.Trades),
+			Low:        t.Low.String(),
+			High:       t.High.String(),
+			Open:       t.Open.String(),
+		})
+	})
+}
+
+func (s *TraderServer) StreamSpreads(req *bth.StreamMarketRequest, stream bth.Trader_StreamSpreadsServer) error {
+	topics := marketTopics(market.ChannelSpread, req.Pairs, 0)
+	match := func(sp *entities.Spread) bool {
+		return containsTopic(topics, market.Topic{Channel: market.ChannelSpread, Pair: sp.Pair})
+	}
+	return streamMarket(stream.Context(), s, s.market.Spreads, topics, match, func(sp *entities.Spread) error {
+		return stream.Send(&bth.Spread{
+			Pair:      sp.Pair,
+			Bid:       sp.Bid.String(),
+			Ask:       sp.Ask.String(),
+			BidVolume: sp.BidVolume.String(),
+			AskVolume: sp.AskVolume.String(),
+			Time:      unixMilli(sp.Time),
+		})
+	})
+}
+
+func (s *TraderServer) StreamPublicTrades(req *bth.StreamMarketRequest, stream bth.Trader_StreamPublicTradesServer) error {
+	topics := marketTopics(market.ChannelTrade, req.Pairs, 0)
+	match := func(t *entities.PublicTrade) bool {
+		return containsTopic(topics, market.Topic{Channel: market.ChannelTrade, Pair: t.Pair})
+	}
+	return streamMarket(stream.Context(), s, s.market.Trades, topics, match, func(t *entities.PublicTrade) error {
+		return stream.Send(&bth.PublicTrade{
+			Pair:      t.Pair,
+			Price:     t.Price.String(),
+			Volume:    t.Volume.String(),
+			Time:      unixMilli(t.Time),
+			Side:      t.Side,
+			OrderType: t.OrderType,
+			Misc:      t.Misc,
+		})
+	})
+}
+
+func (s *TraderServer) StreamCandles(req *bth.StreamCandlesRequest, stream bth.Trader_StreamCandlesServer) error {
+	interval := int(req.Interval)
+	if interval == 0 {
+		interval = defaultInterval
+	}
+	topics := marketTopics(market.ChannelOHLC, req.Pairs, interval)
+	match := func(c *entities.Candle) bool {
+		return containsTopic(topics, market.Topic{Channel: market.ChannelOHLC, Pair: c.Pair, Interval: c.Interval})
+	}
+	return streamMarket(stream.Context(), s, s.market.Candles, topics, match, func(c *entities.Candle) error {
+		return stream.Send(&bth.Candle{
+			Pair:     c.Pair,
+			Interval: int32(c.Interval),
+			Start:    unixMilli(c.Start),
+			End:      unixMilli(c.End),
+			Updated:  unixMilli(c.Updated),
+			Open:     c.Open.String(),
+			High:     c.High.String(),
+			Low:      c.Low.String(),
+			Close:    c.Close.String(),
+			Vwap:     c.Vwap.String(),
+			Volume:   c.Volume.String(),
+			Trades:   int32(c.Trades),
+		})
+	})
+}
+
+// marketTopics creates topics of the channel for every pair
+func marketTopics(channel string, pairs []string, interval int) []market.Topic {
+	topics := make([]market.Topic, 0, len(pairs))
+	for _, pair := range pairs {
+		topics = append(topics, market.Topic{Channel: channel, Pair: pair, Interval: interval})
+	}
+	return topics
+}
+
+func containsTopic(topics []market.Topic, t market.Topic) bool {
+	for _, topic := range topics {
+		if topic == t {
+			return true
+		}
+	}
+	return false
+}
+
+// streamMarket subscribes to the topics and sends matching market data to the stream until the client leaves
+// Kraken is unsubscribed from topics without other listeners when the stream ends.
+func streamMarket[T any](ctx context.Context, s *TraderServer, dispatcher *observer.Subject[T], topics []market.Topic, match func(T) bool, send func(T) error) error {
+	if len(topics) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one pair is required")
+	}
+	// the observer is subscribed before kraken to receive the first update sent after subscription
+	in := &copyObs[T]{
+		ch: make(chan T, 100),
+	}
+	dispatcher.Subscribe(in)
+	defer dispatcher.Unsubscribe(in)
+	release := func(acquired []market.Topic) {
+		for _, t := range acquired {
+			if err := s.market.Subs.Release(t); err != nil {
+				log.Printf("cannot release market subscription: %v", err)
+			}
+		}
+	}
+	for k, t := range topics {
+		if err := s.market.Subs.Acquire(t); err != nil {
+			release(topics[:k])
+			if errors.Is(err, market.ErrInvalidTopic) {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			return status.Errorf(codes.Unavailable, "cannot subscribe to market data: %v", err)
+		}
+	}
+	defer release(topics)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case v := <-in.ch:
+			if !match(v) {
+				continue
+			}
+			if err := send(v); err != nil {
+				log.Printf("cannot send message to outgoing stream: %v", err)
+				return err
+			}
+		}
+	}
+}
@@ -2,116 +2,705 @@ package server
 
 import (
 	"bth-trader/api/bth"
+	"bth-trader/internal/assets"
+	"bth-trader/internal/balances"
+	"bth-trader/internal/book"
+	"bth-trader/internal/decimal"
 	"bth-trader/internal/entities"
 	"bth-trader/internal/kraken"
+	"bth-trader/internal/market"
 	"bth-trader/internal/orders"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"github.com/ltunc/go-observer/observer"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"log"
-	"math/rand"
+	"strings"
+	"sync"
 	"time"
 )
 
+// addOrderTimeout is time to wait for the order confirmation if the client did not set a deadline
+const addOrderTimeout = time.Second * 30
+
+// defaultIdempotencyWindow is time to retain idempotency keys of placed orders
+const defaultIdempotencyWindow = time.Hour * 24
+
+// wsClient sends requests to the exchange, implemented by kraken.WsClient
+type wsClient interface {
+	State() kraken.ConnState
+	LastSeen() time.Time
+	Latency() time.Duration
+	AddOrder(msg kraken.AddOrderMsg) error
+	ValidateOrder(ctx context.Context, msg kraken.AddOrderMsg) (kraken.Reply, error)
+	EditOrder(ctx context.Context, msg kraken.EditOrderMsg) (kraken.Reply, error)
+	CancelOrder(ctx context.Context, msg kraken.CancelOrderMsg) (kraken.Reply, error)
+	CancelAll(ctx context.Context, msg kraken.CancelAllMsg) (kraken.Reply, error)
+}
+
 type TraderServer struct {
 	bth.UnimplementedTraderServer
-	ws      *kraken.WsClient
-	token   *kraken.WsAuthToken
-	rnd     *rand.Rand
-	od      *observer.Subject[*entities.Order]
-	storage *orders.Storage
+	ws      wsClient
+	tokens  *kraken.TokenManager
+	refIds  *orders.Allocator
+	feed    *orders.Feed
+	td      *observer.Subject[*entities.Trade]
+	storage orders.Store
+	market  *market.Market
+	books   *book.Books
+	pairs   *assets.Registry
+	// balances keeps balances of the account and funds reserved by open orders
+	balances *balances.Tracker
+	// IdempotencyWindow is time to retain idempotency keys, repeated requests with the key return the same order
+	IdempotencyWindow time.Duration
 }
 
-func NewTraderServer(ws *kraken.WsClient, token *kraken.WsAuthToken, od *observer.Subject[*entities.Order], storage *orders.Storage) *TraderServer {
+func NewTraderServer(ws *kraken.WsClient, tokens *kraken.TokenManager, td *observer.Subject[*entities.Trade], storage orders.Store, refIds *orders.Allocator, feed *orders.Feed, m *market.Market, books *book.Books, pairs *assets.Registry, balances *balances.Tracker) *TraderServer {
 	return &TraderServer{
-		ws:      ws,
-		token:   token,
-		rnd:     rand.New(rand.NewSource(time.Now().UnixMilli())),
-		od:      od,
-		storage: storage,
+		ws:       ws,
+		tokens:   tokens,
+		refIds:   refIds,
+		feed:     feed,
+		td:       td,
+		storage:  storage,
+		market:   m,
+		books:    books,
+		pairs:    pairs,
+		balances: balances,
+		// keys are retained for a day unless configured otherwise
+		IdempotencyWindow: defaultIdempotencyWindow,
+	}
+}
+
+// checkConnection returns an error if the connection to the exchange is not ready for requests
+func (s *TraderServer) checkConnection() error {
+	if st := s.ws.State(); st != kraken.StateConnected {
+		return status.Errorf(codes.Unavailable, "connection to the exchange is %s", st)
 	}
+	return nil
 }
 
-func (s *TraderServer) AddOrder(_ context.Context, req *bth.AddOrderRequest) (*bth.AddOrderResponse, error) {
-	refId := int(s.rnd.Int31())
+// waitError converts an error of waiting for the order confirmation to gRPC status
+// The status has AddOrderResponse with refId in details, so the client can request the order status later
+func waitError(err error, refId int) error {
+	code := codes.DeadlineExceeded
+	if errors.Is(err, context.Canceled) {
+		code = codes.Canceled
+	}
+	st := status.Newf(code, "no confirmation for order with refId %d: %v", refId, err)
+	if detailed, dErr := st.WithDetails(&bth.AddOrderResponse{Status: "pending", RefId: int32(refId)}); dErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// requestError converts an error of a request to the exchange to gRPC status
+func requestError(err error) error {
+	switch {
+	case errors.Is(err, kraken.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return status.Errorf(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Errorf(codes.Canceled, err.Error())
+	case errors.Is(err, kraken.ErrRejected):
+		return status.Errorf(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, kraken.ErrNotConnected):
+		return status.Errorf(codes.Unavailable, err.Error())
+	}
+	return status.Errorf(codes.Internal, err.Error())
+}
+
+// orderParams converts the request to parameters of the order, limit order is used by default
+// Returns kraken.ErrInvalidOrder if prices or volume are not decimal numbers.
+func orderParams(req *bth.AddOrderRequest) (kraken.OrderParams, error) {
+	p := kraken.OrderParams{
+		Pair:          req.Pair,
+		Direction:     req.Direction,
+		OrderType:     req.OrderType,
+		PriceOffset:   req.PriceOffset,
+		PricePercent:  req.PricePercent,
+		Price2Offset:  req.Price2Offset,
+		Price2Percent: req.Price2Percent,
+		Trigger:       req.Trigger,
+		Leverage:      req.Leverage,
+		OFlags:        req.Oflags,
+		TimeInForce:   req.TimeInForce,
+		StartTm:       req.StartTime,
+		ExpireTm:      req.ExpireTime,
+		Deadline:      req.Deadline,
+		ReduceOnly:    req.ReduceOnly,
+		ValidateOnly:  req.Validate,
+		ClientOrderId: req.ClientOrderId,
+	}
+	if p.OrderType == "" {
+		p.OrderType = kraken.OrderLimit
+	}
+	var err error
+	for _, f := range []struct {
+		name string
+		src  string
+		old  float64
+		dst  *decimal.Decimal
+	}{
+		{"price", req.Price, req.PriceDouble, &p.Price},
+		{"price2", req.Price2, req.Price2Double, &p.Price2},
+		{"volume", req.Volume, req.VolumeDouble, &p.Volume},
+	} {
+		if *f.dst, err = parseAmount(f.src, f.old); err != nil {
+			return p, fmt.Errorf("%w: wrong %s: %v", kraken.ErrInvalidOrder, f.name, err)
+		}
+	}
+	return p, nil
+}
+
+// parseAmount parses a price or a volume of the request
+// The deprecated double sent by old clients is used if the string is empty.
+func parseAmount(s string, old float64) (decimal.Decimal, error) {
+	if s == "" {
+		return decimal.NewFromFloat(old)
+	}
+	return decimal.Parse(s)
+}
+
+func (s *TraderServer) AddOrder(ctx context.Context, req *bth.AddOrderRequest) (*bth.AddOrderResponse, error) {
+	if err := s.checkConnection(); err != nil {
+		return nil, err
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, addOrderTimeout)
+		defer cancel()
+	}
+	params, err := orderParams(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := params.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	// the pair is replaced with its websocket name, so stored orders match updates from the exchange
+	if err := s.pairs.Normalize(&params); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if params.ValidateOnly {
+		return s.validateOrder(ctx, params)
+	}
+	refId, err := s.refIds.Next()
+	if err != nil {
+		return nil, status.Errorf(codes.ResourceExhausted, "cannot allocate refId: %v", err)
+	}
+	if req.IdempotencyKey != "" {
+		claimed, fresh, err := s.storage.Claim(req.IdempotencyKey, fingerprint(params), refId, s.IdempotencyWindow)
+		if errors.Is(err, orders.ErrKeyReused) {
+			s.refIds.Release(refId)
+			return nil, status.Errorf(codes.InvalidArgument, "idempotency key %q: %v", req.IdempotencyKey, err)
+		}
+		if err != nil {
+			log.Printf("cannot persist idempotency key %q: %v", req.IdempotencyKey, err)
+		}
+		if !fresh {
+			// the order was already placed with this key, its result is returned instead of placing it again
+			s.refIds.Release(refId)
+			return s.awaitOrder(ctx, claimed, req.IdempotencyKey)
+		}
+	}
 	orderWaiter := orders.NewWaiter(refId)
-	s.od.Subscribe(orderWaiter)
-	defer s.od.Unsubscribe(orderWaiter)
-	msg := kraken.NewAddOrderMsg(refId, req.Pair, req.Direction, req.Price, req.Volume, s.token.Token)
+	s.storage.Updates().Subscribe(orderWaiter)
+	defer s.storage.Updates().Unsubscribe(orderWaiter)
+	// the order is stored as pending, so a late confirmation will update it in the storage
+	err = s.storage.Insert(&entities.Order{
+		RefId:          refId,
+		ClientOrderId:  params.ClientOrderId,
+		IdempotencyKey: req.IdempotencyKey,
+		Tag:            req.Tag,
+		Pair:           params.Pair,
+		Status:         "pending",
+	})
+	if errors.Is(err, orders.ErrDuplicateClientId) {
+		// a retry with the idempotency key was answered above, so the id is taken by another order
+		s.release(refId, req.IdempotencyKey)
+		return nil, status.Errorf(codes.AlreadyExists, "order with client order id %q already exists", params.ClientOrderId)
+	}
+	if err != nil {
+		s.storage.Remove(refId)
+		s.release(refId, req.IdempotencyKey)
+		return nil, status.Errorf(codes.Internal, "cannot store the order: %v", err)
+	}
+	msg := kraken.NewOrderMsg(refId, params, s.tokens.Token())
 	if err := s.ws.AddOrder(msg); err != nil {
+		s.storage.Remove(refId)
+		s.release(refId, req.IdempotencyKey)
 		return nil, status.Errorf(codes.Internal, "cannot place an order: %v", err)
 	}
-	order := orderWaiter.Wait()
+	order, err := orderWaiter.Wait(ctx)
+	if err != nil {
+		return nil, waitError(err, refId)
+	}
+	return s.addOrderResponse(order)
+}
+
+// awaitOrder returns the result of the order placed with the idempotency key
+// waits for the confirmation if the order is still pending
+func (s *TraderServer) awaitOrder(ctx context.Context, refId int, key string) (*bth.AddOrderResponse, error) {
+	orderWaiter := orders.NewWaiter(refId)
+	s.storage.Updates().Subscribe(orderWaiter)
+	defer s.storage.Updates().Unsubscribe(orderWaiter)
+	order, ok := s.storage.ByIdempotencyKey(key)
+	if !ok || order.Status == "pending" {
+		var err error
+		if order, err = orderWaiter.Wait(ctx); err != nil {
+			return nil, waitError(err, refId)
+		}
+	}
+	return s.addOrderResponse(order)
+}
+
+// addOrderResponse converts the confirmation of the order to the response
+func (s *TraderServer) addOrderResponse(order *entities.Order) (*bth.AddOrderResponse, error) {
 	if order.Status == "error" {
 		return nil, status.Errorf(codes.Internal, "error when placing an order: %v", order.Error)
 	}
+	if stored, ok := s.storage.Find(order.RefId); ok && stored.OrderId == order.OrderId {
+		// the order could be already updated from openOrders channel, e.g. filled immediately
+		order = stored
+	}
 	resp := &bth.AddOrderResponse{
 		Status:  order.Status,
-		RefId:   int32(refId),
+		RefId:   int32(order.RefId),
 		OrderId: order.OrderId,
 	}
 	return resp, nil
 }
 
-func (s *TraderServer) CancelOrder(_ context.Context, req *bth.CancelOrderRequest) (*bth.CancelOrderResponse, error) {
-	refId := int(req.GetRefId())
-	order, ok := s.storage.Find(refId)
-	if !ok {
-		return nil, status.Errorf(codes.NotFound, "cannot find order %v", refId)
+// release frees the refId and the idempotency key of the order which was not sent to the exchange
+func (s *TraderServer) release(refId int, key string) {
+	s.refIds.Release(refId)
+	if key != "" {
+		s.storage.Release(key)
+	}
+}
+
+// fingerprint identifies parameters of the order to detect reuse of an idempotency key for another order
+func fingerprint(p kraken.OrderParams) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%+v", p)))
+	return hex.EncodeToString(sum[:])
+}
+
+// validateOrder asks the exchange to check the order without placing it
+// The order is not placed, so it does not need a refId
+func (s *TraderServer) validateOrder(ctx context.Context, params kraken.OrderParams) (*bth.AddOrderResponse, error) {
+	reply, err := s.ws.ValidateOrder(ctx, kraken.NewOrderMsg(0, params, s.tokens.Token()))
+	if err != nil {
+		return nil, requestError(err)
+	}
+	resp := &bth.AddOrderResponse{Status: "validated"}
+	if reply.Status != "ok" {
+		resp.Status = reply.Status
 	}
-	msg := kraken.NewCancelOrderMsg([]*entities.Order{order}, s.token.Token)
-	if err := s.ws.CancelOrder(msg); err != nil {
-		return nil, status.Errorf(codes.Internal, err.Error())
+	return resp, nil
+}
+
+func (s *TraderServer) EditOrder(ctx context.Context, req *bth.EditOrderRequest) (*bth.EditOrderResponse, error) {
+	if err := s.checkConnection(); err != nil {
+		return nil, err
+	}
+	var price, price2, volume decimal.Decimal
+	for _, f := range []struct {
+		name string
+		src  string
+		old  float64
+		dst  *decimal.Decimal
+	}{
+		{"price", req.Price, req.PriceDouble, &price},
+		{"price2", req.Price2, req.Price2Double, &price2},
+		{"volume", req.Volume, req.VolumeDouble, &volume},
+	} {
+		v, err := parseAmount(f.src, f.old)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "wrong %s: %v", f.name, err)
+		}
+		if v.Sign() < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "price and volume cannot be negative")
+		}
+		*f.dst = v
+	}
+	if price.IsZero() && price2.IsZero() && volume.IsZero() {
+		return nil, status.Errorf(codes.InvalidArgument, "nothing to change")
+	}
+	order, err := s.findOrder(req)
+	if err != nil {
+		return nil, err
+	}
+	if order.OrderId == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "order with refId %d is not confirmed by the exchange yet", order.RefId)
+	}
+	// orders are stored with the websocket name of the pair, as the exchange expects it in editOrder
+	pair, err := s.pairs.Pair(order.Pair)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "pair of the order: %v", err)
+	}
+	for _, p := range []decimal.Decimal{price, price2} {
+		if err := assets.CheckPrice(pair, p); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+	}
+	if err := assets.CheckVolume(pair, volume, strings.Contains(order.OFlags, kraken.FlagViqc)); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	msg := kraken.NewEditOrderMsg(order, order.Pair, price, price2, volume, s.tokens.Token())
+	// the exchange may cancel the edited order before it confirms the edit, the cancellation shall not finish the order
+	s.storage.MarkReplacing(order.OrderId)
+	reply, err := s.ws.EditOrder(ctx, msg)
+	if err != nil {
+		// without the reply the order may still be replaced, so the mark is kept
+		if !errors.Is(err, kraken.ErrTimeout) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+			s.storage.UnmarkReplacing(order.OrderId)
+		}
+		return nil, requestError(err)
+	}
+	resp := &bth.EditOrderResponse{
+		Status:          reply.Status,
+		RefId:           int32(order.RefId),
+		OrderId:         reply.TxId,
+		OriginalOrderId: reply.OriginalTxId,
 	}
-	resp := &bth.CancelOrderResponse{Status: "success"}
 	return resp, nil
 }
 
+func (s *TraderServer) CancelOrder(ctx context.Context, req *bth.CancelOrderRequest) (*bth.CancelOrderResponse, error) {
+	if err := s.checkConnection(); err != nil {
+		return nil, err
+	}
+	order, err := s.findOrder(req)
+	if err != nil {
+		return nil, err
+	}
+	msg := kraken.NewCancelOrderMsg([]*entities.Order{order}, s.tokens.Token())
+	reply, err := s.ws.CancelOrder(ctx, msg)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	resp := &bth.CancelOrderResponse{Status: reply.Status}
+	return resp, nil
+}
+
+func (s *TraderServer) CancelAll(ctx context.Context, req *bth.CancelAllRequest) (*bth.CancelAllResponse, error) {
+	if err := s.checkConnection(); err != nil {
+		return nil, err
+	}
+	if req.Pair == "" {
+		reply, err := s.ws.CancelAll(ctx, kraken.NewCancelAllMsg(s.tokens.Token()))
+		if err != nil {
+			return nil, requestError(err)
+		}
+		return &bth.CancelAllResponse{Status: reply.Status, Count: int32(reply.Count)}, nil
+	}
+	pairName := req.Pair
+	if name, ok := s.pairs.WsName(req.Pair); ok {
+		pairName = name
+	}
+	// the exchange cannot cancel orders by pair, so known open orders of the pair are canceled one by one
+	found := s.storage.Select(func(o *entities.Order) bool {
+		return o.Pair == pairName && o.OrderId != "" && (o.Status == "pending" || o.Status == "open")
+	})
+	if len(found) == 0 {
+		return &bth.CancelAllResponse{Status: "ok"}, nil
+	}
+	reply, err := s.ws.CancelOrder(ctx, kraken.NewCancelOrderMsg(found, s.tokens.Token()))
+	if err != nil {
+		return nil, requestError(err)
+	}
+	if reply.Status != "ok" {
+		return nil, status.Errorf(codes.Unknown, "unexpected status %q of canceling %d orders", reply.Status, len(found))
+	}
+	// cancelOrderStatus has no count, the exchange replies "ok" only if all orders were canceled
+	count := reply.Count
+	if count == 0 {
+		count = len(found)
+	}
+	return &bth.CancelAllResponse{Status: reply.Status, Count: int32(count)}, nil
+}
+
+// orderIdentifier is a request which identifies an order by one of its ids
+type orderIdentifier interface {
+	GetRefId() int32
+	GetOrderId() string
+	GetClientOrderId() string
+}
+
+// findOrder searches the order in the storage by the id set in the request
+func (s *TraderServer) findOrder(req orderIdentifier) (*entities.Order, error) {
+	var order *entities.Order
+	var ok bool
+	var id string
+	switch {
+	case req.GetRefId() != 0:
+		id = fmt.Sprintf("refId %d", req.GetRefId())
+		order, ok = s.storage.Find(int(req.GetRefId()))
+	case req.GetOrderId() != "":
+		id = fmt.Sprintf("orderId %s", req.GetOrderId())
+		order, ok = s.storage.ByOrderId(req.GetOrderId())
+	case req.GetClientOrderId() != "":
+		id = fmt.Sprintf("clientOrderId %s", req.GetClientOrderId())
+		order, ok = s.storage.ByClientOrderId(req.GetClientOrderId())
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "refId, orderId or clientOrderId is required")
+	}
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "cannot find order by %s", id)
+	}
+	return order, nil
+}
+
 func (s *TraderServer) OrderStatus(_ context.Context, req *bth.OrderStatusRequest) (*bth.OrderStatusResponse, error) {
-	refId := int(req.GetRefId())
-	order, ok := s.storage.Find(refId)
+	order, err := s.findOrder(req)
+	if err != nil {
+		return nil, err
+	}
+	return orderResponse(order), nil
+}
+
+func (s *TraderServer) OrderHistory(_ context.Context, req *bth.OrderStatusRequest) (*bth.OrderHistoryResponse, error) {
+	order, err := s.findOrder(req)
+	if err != nil {
+		return nil, err
+	}
+	refId := order.RefId
+	history, ok := s.storage.History(refId)
 	if !ok {
 		return nil, status.Errorf(codes.NotFound, "cannot find order by RefId %d", refId)
 	}
-	resp := &bth.OrderStatusResponse{
-		RefId:   int32(order.RefId),
-		OrderId: order.OrderId,
-		Status:  order.Status,
+	resp := &bth.OrderHistoryResponse{RefId: int32(refId)}
+	for _, tr := range history {
+		resp.Transitions = append(resp.Transitions, &bth.OrderTransition{
+			OrderId: tr.OrderId,
+			From:    tr.From,
+			To:      tr.To,
+			Time:    tr.Time.UnixMilli(),
+		})
+	}
+	return resp, nil
+}
+
+func (s *TraderServer) ListOrders(_ context.Context, req *bth.ListOrdersRequest) (*bth.ListOrdersResponse, error) {
+	q := orders.Query{
+		Statuses:   req.Statuses,
+		Pair:       req.Pair,
+		Side:       req.Side,
+		Tag:        req.Tag,
+		SortBy:     req.SortBy,
+		Descending: req.Descending,
+		Limit:      int(req.PageSize),
+		Cursor:     req.PageToken,
+	}
+	if req.From != 0 {
+		q.From = time.UnixMilli(req.From)
+	}
+	if req.To != 0 {
+		q.To = time.UnixMilli(req.To)
+	}
+	listed, next, err := s.storage.List(q)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	resp := &bth.ListOrdersResponse{NextPageToken: next}
+	for _, l := range listed {
+		o := orderResponse(l.Order)
+		o.CreatedAt = unixMilli(l.Created)
+		o.UpdatedAt = unixMilli(l.Updated)
+		resp.Orders = append(resp.Orders, o)
 	}
 	return resp, nil
 }
 
-// copyObs is observer that sends received order to another channel for consumption
-type copyObs struct {
-	ch chan *entities.Order
+func orderResponse(o *entities.Order) *bth.OrderStatusResponse {
+	return &bth.OrderStatusResponse{
+		RefId:         int32(o.RefId),
+		OrderId:       o.OrderId,
+		Status:        o.Status,
+		Pair:          o.Pair,
+		Side:          o.Side,
+		OrderType:     o.OrderType,
+		Volume:        o.Volume.String(),
+		VolumeExec:    o.VolumeExec.String(),
+		Cost:          o.Cost.String(),
+		Fee:           o.Fee.String(),
+		AvgPrice:      o.AvgPrice.String(),
+		Price:         o.Price.String(),
+		Price2:        o.Price2.String(),
+		LimitPrice:    o.LimitPrice.String(),
+		StopPrice:     o.StopPrice.String(),
+		OpenTime:      unixMilli(o.OpenTime),
+		CloseTime:     unixMilli(o.CloseTime),
+		LastUpdated:   unixMilli(o.LastUpdated),
+		CancelReason:  o.CancelReason,
+		Misc:          o.Misc,
+		Oflags:        o.OFlags,
+		Error:         o.Error,
+		ReplacedId:    o.ReplacedId,
+		ClientOrderId: o.ClientOrderId,
+		Tag:           o.Tag,
+	}
+}
+
+// unixMilli converts time to unix milliseconds, zero time is converted to 0
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// copyObs is observer that sends received event to another channel for consumption
+// If overflow is set, it is closed when an event is dropped, otherwise dropped events are only logged.
+type copyObs[T any] struct {
+	ch       chan T
+	overflow chan struct{}
+	once     sync.Once
 }
 
-func (c *copyObs) Notify(order *entities.Order) {
+func (c *copyObs[T]) Notify(ev T) {
 	select {
-	case c.ch <- order:
+	case c.ch <- ev:
 	default:
-		log.Printf("dropped update %v, channel is full", order)
+		if c.overflow != nil {
+			c.once.Do(func() { close(c.overflow) })
+			return
+		}
+		log.Printf("dropped update %v, channel is full", ev)
 	}
 }
 
-func (s *TraderServer) StreamOrders(_ *bth.Empty, stream bth.Trader_StreamOrdersServer) error {
-	inOrders := &copyObs{
-		ch: make(chan *entities.Order, 100),
+func (s *TraderServer) StreamOrders(req *bth.StreamOrdersRequest, stream bth.Trader_StreamOrdersServer) error {
+	match := orderFilter(req)
+	seq := req.FromSequence
+	if seq == 0 {
+		// the sequence is taken before the snapshot, so updates made during the snapshot are sent after it
+		seq = s.feed.Seq()
+		for _, o := range s.storage.Select(match) {
+			resp := orderResponse(o)
+			resp.Sequence = seq
+			resp.Snapshot = true
+			if err := stream.Send(resp); err != nil {
+				log.Printf("cannot send message to outgoing stream: %v", err)
+				return err
+			}
+		}
 	}
-	s.od.Subscribe(inOrders)
-	defer s.od.Unsubscribe(inOrders)
-	for o := range inOrders.ch {
-		resp := &bth.OrderStatusResponse{
-			RefId:   int32(o.RefId),
-			OrderId: o.OrderId,
-			Status:  o.Status,
+	for {
+		events, wait, err := s.feed.Since(seq)
+		if errors.Is(err, orders.ErrEvicted) {
+			return status.Errorf(codes.OutOfRange, "updates after sequence %d are no longer available, restart the stream without fromSequence", seq)
 		}
-		err := stream.Send(resp)
-		if err != nil {
+		if errors.Is(err, orders.ErrUnknownSeq) {
+			return status.Errorf(codes.OutOfRange, "sequence %d is unknown, the service may have restarted, restart the stream without fromSequence", seq)
+		}
+		for _, ev := range events {
+			seq = ev.Seq
+			if !match(ev.Order) {
+				continue
+			}
+			resp := orderResponse(ev.Order)
+			resp.Sequence = ev.Seq
+			if err := stream.Send(resp); err != nil {
+				log.Printf("cannot send message to outgoing stream: %v", err)
+				return err
+			}
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-wait:
+		}
+	}
+}
+
+// orderFilter creates a function matching orders by filters of the request
+func orderFilter(req *bth.StreamOrdersRequest) func(o *entities.Order) bool {
+	refIds := make(map[int]bool, len(req.RefIds))
+	for _, refId := range req.RefIds {
+		refIds[int(refId)] = true
+	}
+	pairs := make(map[string]bool, len(req.Pairs))
+	for _, pair := range req.Pairs {
+		pairs[pair] = true
+	}
+	statuses := make(map[string]bool, len(req.Statuses))
+	for _, st := range req.Statuses {
+		statuses[st] = true
+	}
+	return func(o *entities.Order) bool {
+		return (len(refIds) == 0 || refIds[o.RefId]) &&
+			(len(pairs) == 0 || pairs[o.Pair]) &&
+			(len(statuses) == 0 || statuses[o.Status])
+	}
+}
+
+// StreamTrades sends own trades as they happen
+// Trades are never dropped silently: if the client is too slow, the stream ends with ResourceExhausted.
+func (s *TraderServer) StreamTrades(req *bth.StreamTradesRequest, stream bth.Trader_StreamTradesServer) error {
+	inTrades := &copyObs[*entities.Trade]{
+		ch:       make(chan *entities.Trade, 100),
+		overflow: make(chan struct{}),
+	}
+	s.td.Subscribe(inTrades)
+	defer s.td.Unsubscribe(inTrades)
+	send := func(t *entities.Trade) error {
+		if (req.Pair != "" && t.Pair != req.Pair) || (req.RefId != 0 && t.RefId != int(req.RefId)) {
+			return nil
+		}
+		if err := stream.Send(tradeResponse(t)); err != nil {
 			log.Printf("cannot send message to outgoing stream: %v", err)
 			return err
 		}
+		return nil
 	}
-	return nil
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case t := <-inTrades.ch:
+			if err := send(t); err != nil {
+				return err
+			}
+		case <-inTrades.overflow:
+			// trades received before the dropped one are still sent
+			for len(inTrades.ch) > 0 {
+				if err := send(<-inTrades.ch); err != nil {
+					return err
+				}
+			}
+			return status.Errorf(codes.ResourceExhausted, "the stream is too slow and trades were dropped, restart the stream and load missed trades from the exchange")
+		}
+	}
+}
+
+func tradeResponse(t *entities.Trade) *bth.Trade {
+	return &bth.Trade{
+		TradeId:    t.TradeId,
+		OrderId:    t.OrderId,
+		PositionId: t.PositionId,
+		RefId:      int32(t.RefId),
+		Pair:       t.Pair,
+		Type:       t.Type,
+		OrderType:  t.OrderType,
+		Price:      t.Price.String(),
+		Volume:     t.Volume.String(),
+		Cost:       t.Cost.String(),
+		Fee:        t.Fee.String(),
+		Margin:     t.Margin.String(),
+		Time:       t.Time.UnixMilli(),
+	}
+}
+
+func (s *TraderServer) Health(_ context.Context, _ *bth.Empty) (*bth.HealthResponse, error) {
+	resp := &bth.HealthResponse{
+		Connection: s.ws.State().String(),
+		LastSeen:   s.ws.LastSeen().UnixMilli(),
+		Latency:    s.ws.Latency().Milliseconds(),
+	}
+	return resp, nil
 }
@@ -0,0 +1,218 @@
+package server
+
+import (
+	"bth-trader/api/bth"
+	"bth-trader/internal/decimal"
+	"bth-trader/internal/entities"
+	"bth-trader/internal/kraken"
+	"bth-trader/internal/trades"
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestOrderParams_oldClient(t *testing.T) {
+	// the request of a client built before prices became strings: pair, direction, price and volume as doubles
+	var msg []byte
+	msg = protowire.AppendTag(msg, 1, protowire.BytesType)
+	msg = protowire.AppendString(msg, "XBT/EUR")
+	msg = protowire.AppendTag(msg, 2, protowire.BytesType)
+	msg = protowire.AppendString(msg, "buy")
+	msg = protowire.AppendTag(msg, 3, protowire.Fixed64Type)
+	msg = protowire.AppendFixed64(msg, math.Float64bits(20000.1))
+	msg = protowire.AppendTag(msg, 4, protowire.Fixed64Type)
+	msg = protowire.AppendFixed64(msg, math.Float64bits(0.00123))
+	req := &bth.AddOrderRequest{}
+	if err := proto.Unmarshal(msg, req); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	p, err := orderParams(req)
+	if err != nil {
+		t.Fatalf("orderParams() error = %v", err)
+	}
+	if p.Price != decimal.MustParse("20000.1") || p.Volume != decimal.MustParse("0.00123") {
+		t.Errorf("orderParams() price = %v, volume = %v, want 20000.1 and 0.00123", p.Price, p.Volume)
+	}
+	// strings take precedence over doubles
+	req.Price = "20000.2"
+	if p, _ := orderParams(req); p.Price != decimal.MustParse("20000.2") {
+		t.Errorf("orderParams() price = %v, want 20000.2", p.Price)
+	}
+	// a double which cannot be kept exactly is rejected instead of becoming zero
+	req.VolumeDouble = 1e-50
+	if _, err := orderParams(req); !errors.Is(err, kraken.ErrInvalidOrder) {
+		t.Errorf("orderParams() error = %v, want ErrInvalidOrder", err)
+	}
+	s, _ := newTestServer(t)
+	edit := &bth.EditOrderRequest{Id: &bth.EditOrderRequest_RefId{RefId: 1}, PriceDouble: math.Inf(1)}
+	if _, err := s.EditOrder(context.Background(), edit); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("EditOrder() error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestTraderServer_EditOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *bth.EditOrderRequest
+		want     kraken.EditOrderMsg
+		wantCode codes.Code
+	}{
+		{
+			name: "by clientOrderId without pair",
+			req:  &bth.EditOrderRequest{Id: &bth.EditOrderRequest_ClientOrderId{ClientOrderId: "my-1"}, Price: "20100.1"},
+			want: kraken.EditOrderMsg{Event: "editOrder", OrderId: "O1", Pair: "XBT/EUR", Price: "20100.1", NewUserRef: "1"},
+		},
+		{
+			name: "only volume",
+			req:  &bth.EditOrderRequest{Id: &bth.EditOrderRequest_OrderId{OrderId: "O1"}, Volume: "0.12345678"},
+			want: kraken.EditOrderMsg{Event: "editOrder", OrderId: "O1", Pair: "XBT/EUR", Volume: "0.12345678", NewUserRef: "1"},
+		},
+		{
+			name:     "price off tick",
+			req:      &bth.EditOrderRequest{Id: &bth.EditOrderRequest_RefId{RefId: 1}, Price: "20100.04"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "volume with too many decimals",
+			req:      &bth.EditOrderRequest{Id: &bth.EditOrderRequest_RefId{RefId: 1}, Volume: "0.123456789"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "not confirmed",
+			req:      &bth.EditOrderRequest{Id: &bth.EditOrderRequest_RefId{RefId: 2}, Price: "20100"},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "unknown order",
+			req:      &bth.EditOrderRequest{Id: &bth.EditOrderRequest_RefId{RefId: 3}, Price: "20100"},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ws := newTestServer(t)
+			s.storage.Add(&entities.Order{RefId: 1, OrderId: "O1", ClientOrderId: "my-1", Pair: "XBT/EUR", Status: "open"})
+			s.storage.Add(&entities.Order{RefId: 2, Pair: "XBT/EUR", Status: "pending"})
+			resp, err := s.EditOrder(context.Background(), tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("EditOrder() error = %v, want %v", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if resp.RefId != 1 || resp.OriginalOrderId != "O1" {
+				t.Errorf("EditOrder() = %v", resp)
+			}
+			if len(ws.edits) != 1 || ws.edits[0] != tt.want {
+				t.Errorf("sent edit requests %+v, want %+v", ws.edits, tt.want)
+			}
+		})
+	}
+}
+
+func TestTraderServer_AddOrder(t *testing.T) {
+	s, ws := newTestServer(t)
+	order := func(clientOrderId, key string) *bth.AddOrderRequest {
+		return &bth.AddOrderRequest{Pair: "XBT/EUR", Direction: "buy", Price: "20000", Volume: "0.01", ClientOrderId: clientOrderId, IdempotencyKey: key}
+	}
+	first, err := s.AddOrder(context.Background(), order("grid-1", "key-1"))
+	if err != nil {
+		t.Fatalf("AddOrder() error = %v", err)
+	}
+	// the retry returns the first order instead of failing on the duplicate client order id
+	retry, err := s.AddOrder(context.Background(), order("grid-1", "key-1"))
+	if err != nil || retry.RefId != first.RefId {
+		t.Errorf("AddOrder() retry = %v, %v, want refId %d", retry, err, first.RefId)
+	}
+	// the duplicate is rejected and its key is released
+	if _, err := s.AddOrder(context.Background(), order("grid-1", "key-2")); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("AddOrder() duplicate error = %v, want AlreadyExists", err)
+	}
+	if _, ok := s.storage.ByIdempotencyKey("key-2"); ok {
+		t.Errorf("key of the duplicate order is claimed")
+	}
+	// refId and key of the order which was not sent are released
+	ws.addErr = errors.New("connection closed")
+	if _, err := s.AddOrder(context.Background(), order("grid-2", "key-3")); status.Code(err) != codes.Internal {
+		t.Errorf("AddOrder() error = %v, want Internal", err)
+	}
+	ws.addErr = nil
+	placed, err := s.AddOrder(context.Background(), order("grid-2", "key-3"))
+	if err != nil {
+		t.Fatalf("AddOrder() after failure error = %v", err)
+	}
+	if placed.RefId != first.RefId+1 {
+		t.Errorf("AddOrder() refId = %d, want released refId %d", placed.RefId, first.RefId+1)
+	}
+}
+
+// mockTradeStream blocks sending until release is closed
+type mockTradeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	// sending receives trades before they are sent
+	sending chan *bth.Trade
+	release chan struct{}
+	sent    []*bth.Trade
+}
+
+func (m *mockTradeStream) Context() context.Context { return m.ctx }
+
+func (m *mockTradeStream) Send(t *bth.Trade) error {
+	select {
+	case m.sending <- t:
+	default:
+	}
+	<-m.release
+	m.sent = append(m.sent, t)
+	return nil
+}
+
+func TestTraderServer_StreamTrades_overflow(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.td = trades.NewDispatcher()
+	stream := &mockTradeStream{ctx: context.Background(), sending: make(chan *bth.Trade, 1), release: make(chan struct{})}
+	done := make(chan error)
+	go func() {
+		done <- s.StreamTrades(&bth.StreamTradesRequest{}, stream)
+	}()
+	// the first trade is fired until the stream is subscribed, then sending of it blocks the stream
+	for subscribed := false; !subscribed; {
+		s.td.Fire(&entities.Trade{TradeId: "first"})
+		select {
+		case <-stream.sending:
+			subscribed = true
+		case <-time.After(time.Millisecond):
+		}
+	}
+	for i := 0; i < 200; i++ {
+		s.td.Fire(&entities.Trade{TradeId: strconv.Itoa(i)})
+	}
+	close(stream.release)
+	if err := <-done; status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("StreamTrades() error = %v, want ResourceExhausted", err)
+	}
+	// trades received before the overflow are sent in order
+	var ids []string
+	for _, tr := range stream.sent {
+		if tr.TradeId != "first" {
+			ids = append(ids, tr.TradeId)
+		}
+	}
+	if len(ids) == 0 || len(ids) > 100 {
+		t.Fatalf("StreamTrades() sent %d trades after the first one, want from 1 to 100", len(ids))
+	}
+	for i, id := range ids {
+		if id != strconv.Itoa(i) {
+			t.Fatalf("StreamTrades() sent trade %s at position %d", id, i)
+		}
+	}
+}
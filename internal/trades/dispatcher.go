@@ -0,0 +1,18 @@
+package trades
+
+import (
+	"bth-trader/internal/entities"
+	"github.com/ltunc/go-observer/observer"
+)
+
+func NewDispatcher() *observer.Subject[*entities.Trade] {
+	return &observer.Subject[*entities.Trade]{}
+}
+
+// ReadFrom reads trades from the channel and fires events in the dispatcher
+// notifies all observers about new trade
+func ReadFrom(dispatcher *observer.Subject[*entities.Trade], input <-chan *entities.Trade) {
+	for trade := range input {
+		dispatcher.Fire(trade)
+	}
+}
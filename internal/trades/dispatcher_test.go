@@ -0,0 +1,31 @@
+package trades
+
+import (
+	"bth-trader/internal/entities"
+	"reflect"
+	"testing"
+)
+
+type mockObserver struct {
+	calls []*entities.Trade
+}
+
+func (m *mockObserver) Notify(t *entities.Trade) {
+	m.calls = append(m.calls, t)
+}
+
+func TestReadFrom(t *testing.T) {
+	input := make(chan *entities.Trade, 10)
+	sent := []*entities.Trade{{TradeId: "T1", RefId: 10}, {TradeId: "T2", RefId: 11}}
+	for _, trade := range sent {
+		input <- trade
+	}
+	close(input)
+	dispatcher := NewDispatcher()
+	obs := &mockObserver{}
+	dispatcher.Subscribe(obs)
+	ReadFrom(dispatcher, input)
+	if !reflect.DeepEqual(obs.calls, sent) {
+		t.Errorf("ReadFrom() got calls %v, want %v", obs.calls, sent)
+	}
+}